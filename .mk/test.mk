@@ -1,6 +1,10 @@
 .PHONY: test
 test:
-	go test -coverprofile=.test.out
+	go test -coverprofile=.test.out ./...
+
+.PHONY: testacc
+testacc:
+	TF_ACC=1 go test -coverprofile=.test.out ./...
 
 .PHONY: cover
 cover: test
@@ -0,0 +1,3 @@
+# Reports whether the DNS API token is valid and the usage of the account
+data "hetzner_dns_account" "current" {
+}
@@ -8,3 +8,9 @@ data "hetzner_dns_zone" "zone_by_name" {
 data "hetzner_dns_records" "opsheaven_all_records" {
   zone_id = "UFWX4H7TP93znuujDkzT9"
 }
+
+# Get records managed by the platform team
+data "hetzner_dns_records" "opsheaven_platform_records" {
+  zone_id      = "UFWX4H7TP93znuujDkzT9"
+  owner_filter = "platform"
+}
@@ -1,4 +1,8 @@
 provider "hetzner" {
   dns_api_enabled = true
   dns_api_token   = "<token>"
+
+  defaults {
+    dns_ttl = 3600
+  }
 }
@@ -0,0 +1,12 @@
+# Reverse zone managed in the same account
+resource "hetzner_dns_zone" "reverse" {
+  name = "2.0.192.in-addr.arpa"
+  ttl  = 3600
+}
+
+# Create PTR records for all A and AAAA records of the zone
+resource "hetzner_dns_reverse_zone_records" "this" {
+  zone_id = hetzner_dns_zone.this.id
+
+  depends_on = [hetzner_dns_zone.reverse]
+}
@@ -1,2 +1,6 @@
 # Zone can be imported by specifying the numeric identifier.
 terraform import hetzner_dns_zone.example QAASDWQ123131ASSDAD
+
+# With the project of the provider as prefix, the import fails when the
+# provider is configured for another project. The id attribute is unchanged.
+terraform import hetzner_dns_zone.example production/QAASDWQ123131ASSDAD
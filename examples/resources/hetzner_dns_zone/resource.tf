@@ -3,3 +3,17 @@ resource "hetzner_dns_zone" "this" {
   name = "opsheaven.space"
   ttl  = 3600
 }
+
+# Create staging zone seeded with the records of the production zone
+resource "hetzner_dns_zone" "staging" {
+  name = "staging.opsheaven.space"
+  ttl  = 3600
+
+  copy_records_from_zone_id = hetzner_dns_zone.this.id
+  copy_rewrite_rules = [
+    {
+      pattern     = "opsheaven\\.space\\.$"
+      replacement = "staging.opsheaven.space."
+    }
+  ]
+}
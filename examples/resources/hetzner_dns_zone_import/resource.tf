@@ -0,0 +1,10 @@
+# Transfer the zone from the self-hosted nameserver and upload the records
+resource "hetzner_dns_zone_import" "this" {
+  zone_name  = "opsheaven.space"
+  nameserver = "ns1.opsheaven.space:53"
+  zone_id    = hetzner_dns_zone.this.id
+
+  tsig_key_name  = "transfer"
+  tsig_algorithm = "hmac-sha256"
+  tsig_secret    = var.tsig_secret
+}
@@ -0,0 +1,209 @@
+// Package api contains the error model shared by the Hetzner API clients.
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+type ErrorCode string
+
+const (
+	ErrorCodeNotFound     ErrorCode = "not_found"
+	ErrorCodeUnauthorized ErrorCode = "unauthorized"
+	ErrorCodeForbidden    ErrorCode = "forbidden"
+	ErrorCodeRateLimited  ErrorCode = "rate_limited"
+	ErrorCodeConflict     ErrorCode = "conflict"
+	ErrorCodeValidation   ErrorCode = "validation"
+	ErrorCodeUnknown      ErrorCode = "unknown"
+)
+
+// Error is an error response of a Hetzner API.
+type Error struct {
+	Code       ErrorCode
+	StatusCode int
+	Message    string
+	// Fields holds the invalid request fields of validation errors.
+	Fields []FieldError
+	// TokenSource describes where the API token was configured, for example
+	// the HETZNER_DNS_API_TOKEN environment variable.
+	TokenSource string
+}
+
+type FieldError struct {
+	Field    string
+	Messages []string
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// NewError creates the error of an API response with an unexpected status.
+// Both the Hetzner DNS and the Hetzner Cloud error bodies are understood.
+func NewError(statusCode int, body []byte, tokenSource string) *Error {
+	apiError := &Error{Code: errorCode(statusCode), StatusCode: statusCode, TokenSource: tokenSource}
+
+	response := struct {
+		Message string `json:"message"`
+		Error   *struct {
+			Message string `json:"message"`
+			Details *struct {
+				Fields []struct {
+					Name     string   `json:"name"`
+					Messages []string `json:"messages"`
+				} `json:"fields"`
+			} `json:"details"`
+		} `json:"error"`
+	}{}
+	if json.Unmarshal(body, &response) != nil {
+		apiError.Message = strings.TrimSpace(string(body))
+		return apiError
+	}
+	apiError.Message = response.Message
+	if response.Error != nil {
+		apiError.Message = response.Error.Message
+		if response.Error.Details != nil {
+			for _, field := range response.Error.Details.Fields {
+				apiError.Fields = append(apiError.Fields, FieldError{Field: field.Name, Messages: field.Messages})
+			}
+		}
+	}
+	return apiError
+}
+
+func errorCode(statusCode int) ErrorCode {
+	switch statusCode {
+	case http.StatusNotFound:
+		return ErrorCodeNotFound
+	case http.StatusUnauthorized:
+		return ErrorCodeUnauthorized
+	case http.StatusForbidden:
+		return ErrorCodeForbidden
+	case http.StatusTooManyRequests:
+		return ErrorCodeRateLimited
+	case http.StatusConflict:
+		return ErrorCodeConflict
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return ErrorCodeValidation
+	}
+	return ErrorCodeUnknown
+}
+
+func IsNotFound(err error) bool {
+	return hasCode(err, ErrorCodeNotFound)
+}
+
+func IsUnauthorized(err error) bool {
+	return hasCode(err, ErrorCodeUnauthorized)
+}
+
+func hasCode(err error, code ErrorCode) bool {
+	var apiError *Error
+	return errors.As(err, &apiError) && apiError.Code == code
+}
+
+// AddError adds the diagnostics describing err. Errors not returned by a
+// Hetzner API keep the generic client error summary. Errors of invalid request
+// fields name the API field in the detail, see WithFieldPaths to attach them
+// to attributes.
+func AddError(diagnostics *diag.Diagnostics, err error) {
+	var apiError *Error
+	if !errors.As(err, &apiError) {
+		diagnostics.AddError("Hetzer Client Error", err.Error())
+		return
+	}
+
+	switch apiError.Code {
+	case ErrorCodeNotFound:
+		diagnostics.Append(notFoundDiagnostic{diag.NewErrorDiagnostic("Hetzner Resource Not Found",
+			fmt.Sprintf("The requested resource does not exist, it may have been deleted outside of Terraform.\n\n%s", apiError))})
+	case ErrorCodeUnauthorized:
+		diagnostics.AddError("Hetzner API Authentication Failed",
+			fmt.Sprintf("The Hetzner API rejected the token from %s. Check that the token is correct and has not been revoked.\n\n%s", apiError.tokenSource(), apiError))
+	case ErrorCodeForbidden:
+		diagnostics.AddError("Hetzner API Permission Denied",
+			fmt.Sprintf("The token from %s is not allowed to perform this operation. Check the permissions of the token.\n\n%s", apiError.tokenSource(), apiError))
+	case ErrorCodeRateLimited:
+		diagnostics.AddError("Hetzner API Rate Limit Exceeded",
+			fmt.Sprintf("The request was still rate limited after all retries. Lower requests_per_second or raise max_retries in the provider configuration.\n\n%s", apiError))
+	case ErrorCodeConflict:
+		diagnostics.AddError("Hetzner Resource Conflict",
+			fmt.Sprintf("The resource conflicts with an existing resource. Import the existing resource or choose a different name.\n\n%s", apiError))
+	case ErrorCodeValidation:
+		if len(apiError.Fields) == 0 {
+			diagnostics.AddError("Invalid Hetzner API Request", apiError.Error())
+		}
+		for _, field := range apiError.Fields {
+			diagnostics.Append(fieldDiagnostic{diag.NewErrorDiagnostic("Invalid Hetzner API Request",
+				fmt.Sprintf("%s: %s", field.Field, strings.Join(field.Messages, ", "))), field.Field})
+		}
+	default:
+		diagnostics.AddError("Hetzer Client Error", apiError.Error())
+	}
+}
+
+// notFoundDiagnostic is the error diagnostic of resources which do not exist.
+type notFoundDiagnostic struct {
+	diag.ErrorDiagnostic
+}
+
+func (d notFoundDiagnostic) Equal(other diag.Diagnostic) bool {
+	o, ok := other.(notFoundDiagnostic)
+	return ok && d.ErrorDiagnostic.Equal(o.ErrorDiagnostic)
+}
+
+// fieldDiagnostic is the error diagnostic of an invalid request field.
+type fieldDiagnostic struct {
+	diag.ErrorDiagnostic
+	field string
+}
+
+func (d fieldDiagnostic) Equal(other diag.Diagnostic) bool {
+	o, ok := other.(fieldDiagnostic)
+	return ok && d.field == o.field && d.ErrorDiagnostic.Equal(o.ErrorDiagnostic)
+}
+
+// WithFieldPaths attaches the errors of invalid request fields to the
+// attributes given by fieldPaths, which maps API field names to attribute
+// paths. API field names are not necessarily attribute names, so errors of
+// fields missing in fieldPaths are kept without a path.
+func WithFieldPaths(diagnostics diag.Diagnostics, fieldPaths map[string]path.Path) diag.Diagnostics {
+	result := make(diag.Diagnostics, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		if d, ok := diagnostic.(fieldDiagnostic); ok {
+			if fieldPath, ok := fieldPaths[d.field]; ok {
+				diagnostic = diag.NewAttributeErrorDiagnostic(fieldPath, d.Summary(), d.Detail())
+			}
+		}
+		result = append(result, diagnostic)
+	}
+	return result
+}
+
+// HasNotFound reports whether the diagnostics contain the error of a resource
+// which does not exist.
+func HasNotFound(diagnostics diag.Diagnostics) bool {
+	for _, diagnostic := range diagnostics {
+		if _, ok := diagnostic.(notFoundDiagnostic); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Error) tokenSource() string {
+	if e.TokenSource == "" {
+		return "the provider configuration"
+	}
+	return e.TokenSource
+}
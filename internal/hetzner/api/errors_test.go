@@ -0,0 +1,88 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestNewError(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		body       string
+		code       ErrorCode
+		message    string
+	}{
+		{http.StatusNotFound, `{"error":{"message":"zone not found","code":404}}`, ErrorCodeNotFound, "zone not found"},
+		{http.StatusUnauthorized, `{"message":"Invalid authentication credentials"}`, ErrorCodeUnauthorized, "Invalid authentication credentials"},
+		{http.StatusForbidden, ``, ErrorCodeForbidden, ""},
+		{http.StatusTooManyRequests, `rate limit exceeded`, ErrorCodeRateLimited, "rate limit exceeded"},
+		{http.StatusConflict, `{"error":{"message":"zone already exists"}}`, ErrorCodeConflict, "zone already exists"},
+		{http.StatusUnprocessableEntity, `{"error":{"message":"invalid input"}}`, ErrorCodeValidation, "invalid input"},
+		{http.StatusInternalServerError, `{}`, ErrorCodeUnknown, ""},
+	}
+	for _, test := range tests {
+		apiError := NewError(test.statusCode, []byte(test.body), "")
+		if apiError.Code != test.code || apiError.Message != test.message {
+			t.Errorf("status %d: expected %s %q, got %s %q", test.statusCode, test.code, test.message, apiError.Code, apiError.Message)
+		}
+	}
+}
+
+func TestAddErrorValidationFields(t *testing.T) {
+	body := `{"error":{"message":"invalid input","details":{"fields":[{"name":"ttl","messages":["must be positive"]}]}}}`
+	diagnostics := diag.Diagnostics{}
+	AddError(&diagnostics, fmt.Errorf("create record: %w", NewError(http.StatusUnprocessableEntity, []byte(body), "")))
+
+	if len(diagnostics) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d", len(diagnostics))
+	}
+	if _, ok := diagnostics[0].(diag.DiagnosticWithPath); ok || diagnostics[0].Detail() != "ttl: must be positive" {
+		t.Errorf("expected diagnostic naming field ttl without a path, got %v", diagnostics[0])
+	}
+
+	diagnostics = WithFieldPaths(diagnostics, map[string]path.Path{"ttl": path.Root("ttl")})
+	withPath, ok := diagnostics[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("ttl")) {
+		t.Errorf("expected diagnostic of attribute ttl, got %v", diagnostics[0])
+	}
+	if diagnostics = WithFieldPaths(diagnostics, nil); len(diagnostics) != 1 {
+		t.Errorf("diagnostics should be kept, got %v", diagnostics)
+	}
+}
+
+func TestAddErrorUnauthorizedNamesTokenSource(t *testing.T) {
+	diagnostics := diag.Diagnostics{}
+	AddError(&diagnostics, NewError(http.StatusUnauthorized, nil, "the HETZNER_DNS_API_TOKEN environment variable"))
+
+	if diagnostics[0].Summary() != "Hetzner API Authentication Failed" || !strings.Contains(diagnostics[0].Detail(), "HETZNER_DNS_API_TOKEN") {
+		t.Errorf("unexpected diagnostic %s: %s", diagnostics[0].Summary(), diagnostics[0].Detail())
+	}
+}
+
+func TestAddErrorKeepsGenericErrors(t *testing.T) {
+	diagnostics := diag.Diagnostics{}
+	AddError(&diagnostics, fmt.Errorf("connection refused"))
+
+	if diagnostics[0].Summary() != "Hetzer Client Error" || diagnostics[0].Detail() != "connection refused" {
+		t.Errorf("unexpected diagnostic %s: %s", diagnostics[0].Summary(), diagnostics[0].Detail())
+	}
+}
+
+func TestHasNotFound(t *testing.T) {
+	notFound := diag.Diagnostics{}
+	AddError(&notFound, NewError(http.StatusNotFound, nil, ""))
+	if !HasNotFound(notFound) || notFound[0].Summary() != "Hetzner Resource Not Found" {
+		t.Errorf("expected not found diagnostic, got %v", notFound)
+	}
+
+	failed := diag.Diagnostics{}
+	AddError(&failed, NewError(http.StatusInternalServerError, nil, ""))
+	if HasNotFound(failed) {
+		t.Errorf("server errors should not be not found, got %v", failed)
+	}
+}
@@ -0,0 +1,8 @@
+package hetzner
+
+// Defaults are the values of the provider defaults block, filling the unset
+// attributes of resources.
+type Defaults struct {
+	// DnsTTL is the ttl of zones and records without ttl, nil without default.
+	DnsTTL *int64
+}
@@ -0,0 +1,83 @@
+package dns
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/api"
+)
+
+type AccountService interface {
+	// Validate verifies the token with a single cheap authenticated request.
+	Validate(ctx context.Context) diag.Diagnostics
+	Read(ctx context.Context, account *Account) diag.Diagnostics
+}
+
+type accountServiceImpl struct {
+	client *zoneClient
+}
+
+var _ AccountService = &accountServiceImpl{}
+
+func newAccountService(client *zoneClient) AccountService {
+	return &accountServiceImpl{client: client}
+}
+
+func (s *accountServiceImpl) Validate(ctx context.Context) diag.Diagnostics {
+	diagnostics := diag.Diagnostics{}
+	if _, _, err := s.client.GetZonePage(ctx, nil, 1, 1); err != nil {
+		api.AddError(&diagnostics, err)
+	}
+	return diagnostics
+}
+
+// Read reports an invalid token as Valid false instead of an error, so the
+// token can be checked with a data source.
+func (s *accountServiceImpl) Read(ctx context.Context, account *Account) diag.Diagnostics {
+	diagnostics := diag.Diagnostics{}
+	account.TokenSource = types.StringValue(s.client.client.tokenSource)
+	account.ZoneCount = types.Int64Null()
+	account.RecordCount = types.Int64Null()
+	account.RateLimit = types.Int64Null()
+	account.RateLimitRemaining = types.Int64Null()
+
+	zones, records := 0, 0
+	for page, lastPage := 1, 1; page <= lastPage; page++ {
+		response, header, err := s.client.GetZonePage(ctx, nil, page, pageSize)
+		if api.IsUnauthorized(err) {
+			account.Valid = types.BoolValue(false)
+			return diagnostics
+		} else if err != nil {
+			api.AddError(&diagnostics, err)
+			return diagnostics
+		}
+		if page == 1 {
+			account.RateLimit = headerInt64(header, "RateLimit-Limit")
+			account.RateLimitRemaining = headerInt64(header, "RateLimit-Remaining")
+		}
+		for _, zone := range response.Zones {
+			zones++
+			if zone.NumberOfRecords != nil {
+				records += *zone.NumberOfRecords
+			}
+		}
+		if response.Meta != nil && response.Meta.Pagination != nil && response.Meta.Pagination.LastPage != nil {
+			lastPage = *response.Meta.Pagination.LastPage
+		}
+	}
+	account.Valid = types.BoolValue(true)
+	account.ZoneCount = types.Int64Value(int64(zones))
+	account.RecordCount = types.Int64Value(int64(records))
+	return diagnostics
+}
+
+func headerInt64(header http.Header, key string) types.Int64 {
+	value, err := strconv.ParseInt(header.Get(key), 10, 64)
+	if err != nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(value)
+}
@@ -0,0 +1,45 @@
+package dns
+
+import (
+	dsSchema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type Account struct {
+	Valid              types.Bool   `tfsdk:"valid"`
+	TokenSource        types.String `tfsdk:"token_source"`
+	ZoneCount          types.Int64  `tfsdk:"zone_count"`
+	RecordCount        types.Int64  `tfsdk:"record_count"`
+	RateLimit          types.Int64  `tfsdk:"rate_limit"`
+	RateLimitRemaining types.Int64  `tfsdk:"rate_limit_remaining"`
+}
+
+var AccountDataSourceSchema = dsSchema.Schema{
+	MarkdownDescription: "Hetzner DNS Account Data Source. Reports whether the configured DNS API token is valid and the usage of the account.",
+	Attributes: map[string]dsSchema.Attribute{
+		"valid": dsSchema.BoolAttribute{
+			MarkdownDescription: "Whether the DNS API accepted the token. The other attributes are null for invalid tokens.",
+			Computed:            true,
+		},
+		"token_source": dsSchema.StringAttribute{
+			MarkdownDescription: "Where the provider read the token from, e.g. the `HETZNER_DNS_API_TOKEN` environment variable.",
+			Computed:            true,
+		},
+		"zone_count": dsSchema.Int64Attribute{
+			MarkdownDescription: "Number of zones of the account",
+			Computed:            true,
+		},
+		"record_count": dsSchema.Int64Attribute{
+			MarkdownDescription: "Number of records in all zones of the account",
+			Computed:            true,
+		},
+		"rate_limit": dsSchema.Int64Attribute{
+			MarkdownDescription: "Request limit of the current rate limit window, null when not reported by the API.",
+			Computed:            true,
+		},
+		"rate_limit_remaining": dsSchema.Int64Attribute{
+			MarkdownDescription: "Remaining requests of the current rate limit window, null when not reported by the API.",
+			Computed:            true,
+		},
+	},
+}
@@ -0,0 +1,116 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	miekgdns "github.com/miekg/dns"
+)
+
+const axfrTimeout = 30 * time.Second
+
+type TSIGKey struct {
+	Name      string
+	Algorithm string
+	Secret    string
+}
+
+// transferZone performs an AXFR of the zone against the nameserver and
+// converts the transferred resource records into records. Records of types
+// not supported by Hetzner are skipped and returned by type.
+func transferZone(ctx context.Context, zoneName, nameserver string, key *TSIGKey) (*Records, []string, error) {
+	zoneName = miekgdns.Fqdn(zoneName)
+	if _, _, err := net.SplitHostPort(nameserver); err != nil {
+		nameserver = net.JoinHostPort(nameserver, "53")
+	}
+
+	message := new(miekgdns.Msg)
+	message.SetAxfr(zoneName)
+	transfer := &miekgdns.Transfer{
+		ReadTimeout:  axfrTimeout,
+		WriteTimeout: axfrTimeout,
+	}
+	if key != nil {
+		keyName := miekgdns.Fqdn(key.Name)
+		algorithm := miekgdns.Fqdn(key.Algorithm)
+		transfer.TsigSecret = map[string]string{keyName: key.Secret}
+		message.SetTsig(keyName, algorithm, 300, time.Now().Unix())
+	}
+
+	dialer := &net.Dialer{Timeout: axfrTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", nameserver)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer conn.Close()
+	// Closing the connection aborts a running transfer on cancellation.
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+	transfer.Conn = &miekgdns.Conn{Conn: conn}
+
+	envelopes, err := transfer.In(message, nameserver)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	records := &Records{Records: []Record{}}
+	skipped := []string{}
+	for envelope := range envelopes {
+		if envelope.Error != nil {
+			if ctx.Err() != nil {
+				return nil, nil, ctx.Err()
+			}
+			return nil, nil, envelope.Error
+		}
+		for _, rr := range envelope.RR {
+			record, ok := recordFromRR(zoneName, rr)
+			if !ok {
+				recordType := miekgdns.TypeToString[rr.Header().Rrtype]
+				if !slices.Contains(skipped, recordType) {
+					skipped = append(skipped, recordType)
+				}
+				continue
+			}
+			records.Records = append(records.Records, record)
+		}
+	}
+	if len(records.Records) == 0 {
+		return nil, nil, fmt.Errorf("zone transfer of %s from %s returned no records", zoneName, nameserver)
+	}
+	// AXFR responses start and end with the SOA record of the zone.
+	if last := len(records.Records) - 1; last > 0 && records.Records[last].Type.ValueString() == "SOA" {
+		records.Records = records.Records[:last]
+	}
+	return records, skipped, nil
+}
+
+func recordFromRR(zoneName string, rr miekgdns.RR) (Record, bool) {
+	header := rr.Header()
+	recordType := miekgdns.TypeToString[header.Rrtype]
+	if !slices.Contains(allowedRecordTypes, recordType) {
+		return Record{}, false
+	}
+
+	name := "@"
+	if !strings.EqualFold(header.Name, zoneName) {
+		name = strings.TrimSuffix(header.Name, "."+zoneName)
+	}
+	value := strings.TrimPrefix(rr.String(), header.String())
+
+	return Record{
+		Id:            types.StringNull(),
+		Type:          types.StringValue(recordType),
+		ZoneId:        types.StringNull(),
+		Name:          NewDomainNameValue(name),
+		NameUnicode:   types.StringValue(toUnicodeName(name)),
+		Value:         types.StringValue(value),
+		TTL:           types.Int64Value(int64(header.Ttl)),
+		Owner:         types.StringNull(),
+		OwnerRecordId: types.StringNull(),
+	}, true
+}
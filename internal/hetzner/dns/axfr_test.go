@@ -0,0 +1,85 @@
+package dns
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/dns/dnstest"
+)
+
+const testTSIGSecret = "c2VjcmV0LXNlY3JldC1zZWNyZXQ="
+
+var testZoneRecords = []string{
+	"example.com. 3600 IN SOA ns1.example.com. hostmaster.example.com. 1 7200 3600 1209600 3600",
+	"example.com. 3600 IN NS ns1.example.com.",
+	"example.com. 300 IN A 192.0.2.1",
+	"www.example.com. 300 IN CNAME example.com.",
+	"example.com. 300 IN MX 10 mail.example.com.",
+	"example.com. 300 IN TXT \"v=spf1 -all\"",
+	"example.com. 300 IN DNSKEY 256 3 13 AAAA",
+}
+
+func TestTransferZone(t *testing.T) {
+	nameserver := dnstest.StartAXFRServer(t, "example.com.", testZoneRecords, "")
+
+	records, skipped, err := transferZone(context.Background(), "example.com", nameserver, nil)
+	if err != nil {
+		t.Fatalf("zone transfer failed: %s", err)
+	}
+	if len(skipped) != 1 || skipped[0] != "DNSKEY" {
+		t.Errorf("DNSKEY records should be skipped, got %v", skipped)
+	}
+
+	expected := []struct{ name, recordType, value string }{
+		{"@", "SOA", "ns1.example.com. hostmaster.example.com. 1 7200 3600 1209600 3600"},
+		{"@", "NS", "ns1.example.com."},
+		{"@", "A", "192.0.2.1"},
+		{"www", "CNAME", "example.com."},
+		{"@", "MX", "10 mail.example.com."},
+		{"@", "TXT", "\"v=spf1 -all\""},
+	}
+	if len(records.Records) != len(expected) {
+		t.Fatalf("expected %d records, got %d", len(expected), len(records.Records))
+	}
+	for i, e := range expected {
+		record := records.Records[i]
+		if record.Name.ValueString() != e.name || record.Type.ValueString() != e.recordType || record.Value.ValueString() != e.value {
+			t.Errorf("record %d is %s %s %s, expected %s %s %s", i,
+				record.Name.ValueString(), record.Type.ValueString(), record.Value.ValueString(), e.name, e.recordType, e.value)
+		}
+	}
+	if !isUploadableRecord(records.Records[2]) || isUploadableRecord(records.Records[0]) || isUploadableRecord(records.Records[1]) {
+		t.Error("SOA and apex NS records should not be uploaded")
+	}
+}
+
+func TestTransferZoneWithTSIG(t *testing.T) {
+	nameserver := dnstest.StartAXFRServer(t, "example.com.", testZoneRecords, testTSIGSecret)
+
+	if _, _, err := transferZone(context.Background(), "example.com", nameserver, nil); err == nil {
+		t.Error("unsigned zone transfer should fail")
+	}
+
+	key := &TSIGKey{Name: "transfer", Algorithm: "hmac-sha256", Secret: testTSIGSecret}
+	records, _, err := transferZone(context.Background(), "example.com", nameserver, key)
+	if err != nil {
+		t.Fatalf("signed zone transfer failed: %s", err)
+	}
+	if len(records.Records) != 6 {
+		t.Errorf("expected 6 records, got %d", len(records.Records))
+	}
+}
+
+func TestTransferZoneUnreachableNameserver(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	nameserver := listener.Addr().String()
+	listener.Close()
+
+	if _, _, err := transferZone(context.Background(), "example.com", nameserver, nil); err == nil {
+		t.Error("zone transfer from unreachable nameserver should fail")
+	}
+}
@@ -0,0 +1,150 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	zonesTag        = "zones"
+	zoneTagPrefix   = "zone:"
+	recordTagPrefix = "record:"
+)
+
+func zoneTag(zoneId *string) string {
+	if zoneId == nil {
+		return zoneTagPrefix
+	}
+	return zoneTagPrefix + *zoneId
+}
+
+func recordTag(recordId *string) string {
+	if recordId == nil {
+		return recordTagPrefix
+	}
+	return recordTagPrefix + *recordId
+}
+
+// responseCache caches the bodies of GET responses for a TTL. Entries are
+// tagged with the zones and records they contain, so writes invalidate only
+// the responses of the changed zone. Concurrent requests of a missing entry
+// share a single API request.
+type responseCache struct {
+	ttl     time.Duration
+	now     func() time.Time
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+type cacheEntry struct {
+	done    chan struct{}
+	loaded  bool
+	expires time.Time
+	tags    []string
+	data    []byte
+	err     error
+}
+
+// cacheLoader performs the request of a missing entry and returns the
+// response body with its tags.
+type cacheLoader func() ([]byte, []string, error)
+
+func newResponseCache(ttl time.Duration) *responseCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &responseCache{ttl: ttl, now: time.Now, entries: map[string]*cacheEntry{}}
+}
+
+// load returns the cached response of key or loads it. A nil cache always
+// loads.
+func (c *responseCache) load(ctx context.Context, key string, loader cacheLoader) ([]byte, error) {
+	if c == nil {
+		data, _, err := loader()
+		return data, err
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if ok && !entry.expired(c.now()) {
+		c.mu.Unlock()
+		select {
+		case <-entry.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		// The request of another caller was cancelled, retry with ours.
+		if isContextError(entry.err) && ctx.Err() == nil {
+			return c.load(ctx, key, loader)
+		}
+		return entry.data, entry.err
+	}
+	entry = &cacheEntry{done: make(chan struct{})}
+	c.entries[key] = entry
+	c.mu.Unlock()
+
+	data, tags, err := loader()
+
+	c.mu.Lock()
+	entry.data, entry.tags, entry.err = data, tags, err
+	entry.loaded = true
+	entry.expires = c.now().Add(c.ttl)
+	if err != nil && c.entries[key] == entry {
+		delete(c.entries, key)
+	}
+	c.mu.Unlock()
+	close(entry.done)
+	return data, err
+}
+
+// invalidate removes the entries with any of the tags. Entries tagged with a
+// record also invalidate the zones of the record.
+func (c *responseCache) invalidate(tags ...string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, entry := range c.entries {
+		if !entry.pending() && entry.tagged(tags) {
+			for _, tag := range entry.tags {
+				if strings.HasPrefix(tag, zoneTagPrefix) && !slices.Contains(tags, tag) {
+					tags = append(tags, tag)
+				}
+			}
+		}
+	}
+	for key, entry := range c.entries {
+		// Pending entries may be loaded before the write, they are dropped
+		// as their tags are not known yet.
+		if entry.pending() || entry.tagged(tags) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+func (e *cacheEntry) pending() bool {
+	return !e.loaded
+}
+
+func (e *cacheEntry) expired(now time.Time) bool {
+	return e.loaded && !now.Before(e.expires)
+}
+
+func (e *cacheEntry) tagged(tags []string) bool {
+	for _, tag := range e.tags {
+		if slices.Contains(tags, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
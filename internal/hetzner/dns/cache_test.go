@@ -0,0 +1,154 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/dns/dnstest"
+)
+
+func TestResponseCacheSharesConcurrentLoads(t *testing.T) {
+	cache := newResponseCache(time.Minute)
+	var loads atomic.Int32
+	release := make(chan struct{})
+	loader := func() ([]byte, []string, error) {
+		loads.Add(1)
+		<-release
+		return []byte("data"), []string{zonesTag}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if data, err := cache.load(context.Background(), "key", loader); err != nil || string(data) != "data" {
+				t.Errorf("unexpected response %q: %v", data, err)
+			}
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if loads.Load() != 1 {
+		t.Errorf("expected 1 load, got %d", loads.Load())
+	}
+}
+
+func TestResponseCacheExpiresEntries(t *testing.T) {
+	cache := newResponseCache(time.Minute)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	loads := 0
+	loader := func() ([]byte, []string, error) {
+		loads++
+		return []byte("data"), nil, nil
+	}
+
+	cache.load(context.Background(), "key", loader)
+	cache.load(context.Background(), "key", loader)
+	now = now.Add(time.Minute)
+	cache.load(context.Background(), "key", loader)
+	if loads != 2 {
+		t.Errorf("expected 2 loads, got %d", loads)
+	}
+}
+
+func TestResponseCacheDoesNotCacheErrors(t *testing.T) {
+	cache := newResponseCache(time.Minute)
+	loads := 0
+	loader := func() ([]byte, []string, error) {
+		loads++
+		return nil, nil, errors.New("failed")
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := cache.load(context.Background(), "key", loader); err == nil {
+			t.Error("expected error")
+		}
+	}
+	if loads != 2 {
+		t.Errorf("expected 2 loads, got %d", loads)
+	}
+}
+
+func TestResponseCacheInvalidatesZones(t *testing.T) {
+	cache := newResponseCache(time.Minute)
+	zone1, zone2, record := "zone1", "zone2", "record"
+	entries := map[string][]string{
+		"zones":   {zonesTag},
+		"zone1":   {zoneTag(&zone1)},
+		"records": {zoneTag(&zone1), recordTag(&record)},
+		"zone2":   {zoneTag(&zone2)},
+	}
+	load := func() map[string]int {
+		loads := map[string]int{}
+		for key, tags := range entries {
+			cache.load(context.Background(), key, func() ([]byte, []string, error) {
+				loads[key]++
+				return []byte(key), tags, nil
+			})
+		}
+		return loads
+	}
+	load()
+
+	// The record belongs to zone1, so all its responses are invalidated.
+	cache.invalidate(zonesTag, recordTag(&record))
+	loads := load()
+	if len(loads) != 3 || loads["zone2"] != 0 {
+		t.Errorf("expected zone1 and zone list reloads, got %v", loads)
+	}
+}
+
+func TestNilResponseCacheAlwaysLoads(t *testing.T) {
+	cache := newResponseCache(0)
+	loads := 0
+	for i := 0; i < 2; i++ {
+		cache.load(context.Background(), "key", func() ([]byte, []string, error) {
+			loads++
+			return nil, nil, nil
+		})
+	}
+	cache.invalidate(zonesTag)
+	if loads != 2 {
+		t.Errorf("expected 2 loads, got %d", loads)
+	}
+}
+
+func TestServicesCacheReads(t *testing.T) {
+	server := dnstest.NewServer(t)
+	services, _ := NewClient(Config{Token: dnstest.Token, Endpoint: server.URL, CacheTTL: time.Minute}, http.DefaultClient)
+	ctx := context.Background()
+	zone := server.AddZone("example.com", 3600)
+	other := server.AddZone("example.org", 3600)
+
+	for i := 0; i < 3; i++ {
+		services.ZoneService().List(ctx, &Zones{Name: types.StringNull()})
+		services.RecordService().List(ctx, &Records{ZoneId: types.StringValue(zone.Id), OwnerFilter: types.StringNull()})
+		services.RecordService().List(ctx, &Records{ZoneId: types.StringValue(other.Id), OwnerFilter: types.StringNull()})
+	}
+	if requests := server.Requests(); requests != 3 {
+		t.Fatalf("expected 3 requests, got %d", requests)
+	}
+
+	if diags := services.RecordService().Create(ctx, newTestRecord(zone.Id, "A", "www", "192.0.2.1", 300)); diags.HasError() {
+		t.Fatalf("create failed: %v", diags)
+	}
+	records := &Records{ZoneId: types.StringValue(zone.Id), OwnerFilter: types.StringNull()}
+	services.RecordService().List(ctx, records)
+	services.RecordService().List(ctx, &Records{ZoneId: types.StringValue(other.Id), OwnerFilter: types.StringNull()})
+	if len(records.Records) != 5 {
+		t.Errorf("records of the changed zone should be reloaded, got %d records", len(records.Records))
+	}
+	// Create and reload of the changed zone only.
+	if requests := server.Requests(); requests != 5 {
+		t.Errorf("expected 5 requests, got %d", requests)
+	}
+}
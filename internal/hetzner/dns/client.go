@@ -0,0 +1,339 @@
+package dns
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/opsheaven/gohetznerdns"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/api"
+)
+
+const (
+	DefaultEndpoint = "https://dns.hetzner.com/api/v1"
+	zonesBasePath   = "/zones"
+	recordsBasePath = "/records"
+	pageSize        = 100
+)
+
+// apiClient is a minimal client for the Hetzner DNS Public API
+// [https://dns.hetzner.com/api-docs]. Requests are sent with the given
+// http.Client so that the provider controls transport behaviour.
+type apiClient struct {
+	httpClient  *http.Client
+	baseURL     string
+	token       string
+	tokenSource string
+	cache       *responseCache
+}
+
+func newAPIClient(httpClient *http.Client, config Config) (*apiClient, error) {
+	if err := validateNotEmpty("token", &config.Token); err != nil {
+		return nil, err
+	}
+	baseURL := DefaultEndpoint
+	if config.Endpoint != "" {
+		endpoint, err := url.Parse(config.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+		if (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+			return nil, fmt.Errorf("endpoint %q must be an absolute http or https URL", config.Endpoint)
+		}
+		baseURL = strings.TrimSuffix(config.Endpoint, "/")
+	}
+	return &apiClient{httpClient: httpClient, baseURL: baseURL, token: config.Token, tokenSource: config.TokenSource, cache: newResponseCache(config.CacheTTL)}, nil
+}
+
+func (c *apiClient) execute(ctx context.Context, method, path string, query url.Values, body, result interface{}, expectedStatusCodes ...int) error {
+	_, err := c.do(ctx, method, path, query, body, result, expectedStatusCodes...)
+	return err
+}
+
+// do executes the request like execute and also returns the response headers.
+func (c *apiClient) do(ctx context.Context, method, path string, query url.Values, body, result interface{}, expectedStatusCodes ...int) (http.Header, error) {
+	header, data, err := c.roundTrip(ctx, method, path, query, body, expectedStatusCodes...)
+	if err != nil {
+		return header, err
+	}
+	if result != nil && len(data) > 0 {
+		return header, json.Unmarshal(data, result)
+	}
+	return header, nil
+}
+
+// get executes a GET request, served from the response cache when enabled.
+// tags returns the cache tags of the decoded result.
+func (c *apiClient) get(ctx context.Context, path string, query url.Values, result interface{}, tags func() []string) error {
+	decoded := false
+	data, err := c.cache.load(ctx, path+"?"+query.Encode(), func() ([]byte, []string, error) {
+		_, data, err := c.roundTrip(ctx, http.MethodGet, path, query, nil, http.StatusOK)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := json.Unmarshal(data, result); err != nil {
+			return nil, nil, err
+		}
+		decoded = true
+		return data, tags(), nil
+	})
+	if err != nil || decoded {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+func (c *apiClient) roundTrip(ctx context.Context, method, path string, query url.Values, body interface{}, expectedStatusCodes ...int) (http.Header, []byte, error) {
+	endpoint := c.baseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	request, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	request.Header.Set("Content-Type", "application/json; charset=utf-8")
+	request.Header.Set("Auth-API-Token", c.token)
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer response.Body.Close()
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !slices.Contains(expectedStatusCodes, response.StatusCode) {
+		return response.Header, nil, api.NewError(response.StatusCode, data, c.tokenSource)
+	}
+	return response.Header, data, nil
+}
+
+func validateNotEmpty(parameterName string, value *string) error {
+	if value == nil {
+		return fmt.Errorf("900 : %s is nil", parameterName)
+	}
+	if len(strings.TrimSpace(*value)) == 0 {
+		return fmt.Errorf("901 : %s is empty", parameterName)
+	}
+	return nil
+}
+
+type recordClient struct {
+	client *apiClient
+}
+
+func (c *recordClient) GetAllRecords(ctx context.Context, zoneId *string) ([]*gohetznerdns.Record, error) {
+	if err := validateNotEmpty("zone_id", zoneId); err != nil {
+		return nil, err
+	}
+	var records []*gohetznerdns.Record
+	for page, lastPage := 1, 1; page <= lastPage; page++ {
+		query := url.Values{}
+		query.Set("zone_id", *zoneId)
+		query.Set("page", fmt.Sprint(page))
+		query.Set("per_page", fmt.Sprint(pageSize))
+
+		response := &struct {
+			Records []*gohetznerdns.Record `json:"records"`
+			Meta    *gohetznerdns.Meta     `json:"meta"`
+		}{}
+		tags := func() []string {
+			tags := []string{zoneTag(zoneId)}
+			for _, record := range response.Records {
+				tags = append(tags, recordTag(record.Id))
+			}
+			return tags
+		}
+		if err := c.client.get(ctx, recordsBasePath, query, response, tags); err != nil {
+			return nil, err
+		}
+		records = append(records, response.Records...)
+		if response.Meta != nil && response.Meta.Pagination != nil && response.Meta.Pagination.LastPage != nil {
+			lastPage = *response.Meta.Pagination.LastPage
+		}
+	}
+	return records, nil
+}
+
+func (c *recordClient) GetRecord(ctx context.Context, recordId *string) (*gohetznerdns.Record, error) {
+	if err := validateNotEmpty("record_id", recordId); err != nil {
+		return nil, err
+	}
+	response := new(gohetznerdns.RecordResponse)
+	tags := func() []string {
+		if response.Record == nil {
+			return []string{recordTag(recordId)}
+		}
+		return []string{recordTag(recordId), zoneTag(response.Record.ZoneId)}
+	}
+	if err := c.client.get(ctx, recordsBasePath+"/"+*recordId, nil, response, tags); err != nil {
+		return nil, err
+	}
+	return response.Record, nil
+}
+
+func (c *recordClient) CreateRecord(ctx context.Context, request *gohetznerdns.Record) (*gohetznerdns.Record, error) {
+	response := new(gohetznerdns.RecordResponse)
+	err := c.client.execute(ctx, http.MethodPost, recordsBasePath, nil, request, response, http.StatusOK)
+	c.client.cache.invalidate(zonesTag, zoneTag(request.ZoneId))
+	if err != nil {
+		return nil, err
+	}
+	return response.Record, nil
+}
+
+// BulkCreateRecords creates the records with a single request. The API
+// creates the valid records and returns the rejected ones as invalid records
+// instead of failing the request.
+func (c *recordClient) BulkCreateRecords(ctx context.Context, requests []*gohetznerdns.Record) ([]*gohetznerdns.Record, []*gohetznerdns.Record, error) {
+	response := &struct {
+		Records        []*gohetznerdns.Record `json:"records"`
+		InvalidRecords []*gohetznerdns.Record `json:"invalid_records"`
+	}{}
+	err := c.client.execute(ctx, http.MethodPost, recordsBasePath+"/bulk", nil, &gohetznerdns.Records{Records: requests}, response, http.StatusOK)
+	tags := []string{zonesTag}
+	for _, request := range requests {
+		tags = append(tags, zoneTag(request.ZoneId))
+	}
+	c.client.cache.invalidate(tags...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return response.Records, response.InvalidRecords, nil
+}
+
+func (c *recordClient) UpdateRecord(ctx context.Context, request *gohetznerdns.Record) (*gohetznerdns.Record, error) {
+	if err := validateNotEmpty("record_id", request.Id); err != nil {
+		return nil, err
+	}
+	response := new(gohetznerdns.RecordResponse)
+	err := c.client.execute(ctx, http.MethodPut, recordsBasePath+"/"+*request.Id, nil, request, response, http.StatusOK)
+	c.client.cache.invalidate(zonesTag, zoneTag(request.ZoneId), recordTag(request.Id))
+	if err != nil {
+		return nil, err
+	}
+	return response.Record, nil
+}
+
+func (c *recordClient) DeleteRecord(ctx context.Context, recordId *string) error {
+	if err := validateNotEmpty("record_id", recordId); err != nil {
+		return err
+	}
+	err := c.client.execute(ctx, http.MethodDelete, recordsBasePath+"/"+*recordId, nil, nil, nil, http.StatusOK, http.StatusNotFound)
+	c.client.cache.invalidate(zonesTag, recordTag(recordId))
+	return err
+}
+
+type zoneClient struct {
+	client *apiClient
+}
+
+func (c *zoneClient) GetAllZones(ctx context.Context) ([]*gohetznerdns.Zone, error) {
+	return c.GetAllZonesByName(ctx, nil)
+}
+
+func (c *zoneClient) GetAllZonesByName(ctx context.Context, name *string) ([]*gohetznerdns.Zone, error) {
+	var zones []*gohetznerdns.Zone
+	for page, lastPage := 1, 1; page <= lastPage; page++ {
+		response := new(gohetznerdns.ZoneList)
+		tags := func() []string { return []string{zonesTag} }
+		if err := c.client.get(ctx, zonesBasePath, zonesQuery(name, page, pageSize), response, tags); err != nil {
+			return nil, err
+		}
+		zones = append(zones, response.Zones...)
+		if response.Meta != nil && response.Meta.Pagination != nil && response.Meta.Pagination.LastPage != nil {
+			lastPage = *response.Meta.Pagination.LastPage
+		}
+	}
+	return zones, nil
+}
+
+// GetZonePage returns a single page of zones with the response headers. The
+// response is not cached.
+func (c *zoneClient) GetZonePage(ctx context.Context, name *string, page, perPage int) (*gohetznerdns.ZoneList, http.Header, error) {
+	response := new(gohetznerdns.ZoneList)
+	header, err := c.client.do(ctx, http.MethodGet, zonesBasePath, zonesQuery(name, page, perPage), nil, response, http.StatusOK)
+	if err != nil {
+		return nil, nil, err
+	}
+	return response, header, nil
+}
+
+func zonesQuery(name *string, page, perPage int) url.Values {
+	query := url.Values{}
+	query.Set("page", fmt.Sprint(page))
+	query.Set("per_page", fmt.Sprint(perPage))
+	if name != nil {
+		query.Set("search_name", *name)
+	}
+	return query
+}
+
+func (c *zoneClient) GetZoneById(ctx context.Context, zoneId *string) (*gohetznerdns.Zone, error) {
+	if err := validateNotEmpty("zoneId", zoneId); err != nil {
+		return nil, err
+	}
+	response := new(gohetznerdns.ZoneResponse)
+	tags := func() []string { return []string{zoneTag(zoneId)} }
+	if err := c.client.get(ctx, zonesBasePath+"/"+*zoneId, nil, response, tags); err != nil {
+		return nil, err
+	}
+	return zoneFromResponse(response)
+}
+
+func (c *zoneClient) CreateZone(ctx context.Context, request *gohetznerdns.ZoneRequest) (*gohetznerdns.Zone, error) {
+	response := new(gohetznerdns.ZoneResponse)
+	err := c.client.execute(ctx, http.MethodPost, zonesBasePath, nil, request, response, http.StatusOK, http.StatusCreated)
+	c.client.cache.invalidate(zonesTag)
+	if err != nil {
+		return nil, err
+	}
+	return zoneFromResponse(response)
+}
+
+func (c *zoneClient) UpdateZone(ctx context.Context, zoneId *string, request *gohetznerdns.ZoneRequest) (*gohetznerdns.Zone, error) {
+	if err := validateNotEmpty("zoneId", zoneId); err != nil {
+		return nil, err
+	}
+	response := new(gohetznerdns.ZoneResponse)
+	err := c.client.execute(ctx, http.MethodPut, zonesBasePath+"/"+*zoneId, nil, request, response, http.StatusOK)
+	c.client.cache.invalidate(zonesTag, zoneTag(zoneId))
+	if err != nil {
+		return nil, err
+	}
+	return zoneFromResponse(response)
+}
+
+func (c *zoneClient) DeleteZone(ctx context.Context, zoneId *string) error {
+	if err := validateNotEmpty("zoneId", zoneId); err != nil {
+		return err
+	}
+	err := c.client.execute(ctx, http.MethodDelete, zonesBasePath+"/"+*zoneId, nil, nil, nil, http.StatusOK, http.StatusNotFound)
+	c.client.cache.invalidate(zonesTag, zoneTag(zoneId))
+	return err
+}
+
+func zoneFromResponse(response *gohetznerdns.ZoneResponse) (*gohetznerdns.Zone, error) {
+	if response.Error != nil {
+		return nil, response.Error.Error()
+	}
+	return response.Zone, nil
+}
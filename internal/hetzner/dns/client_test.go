@@ -0,0 +1,61 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAPIClientStopsOnCancellation(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client, err := newAPIClient(server.Client(), Config{Token: "token", Endpoint: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = client.execute(ctx, http.MethodGet, zonesBasePath, nil, nil, nil, http.StatusOK)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("cancelled request should stop promptly, took %s", elapsed)
+	}
+}
+
+func TestAPIClientEndpoint(t *testing.T) {
+	tests := map[string]string{
+		"":                               DefaultEndpoint,
+		"http://localhost:8080/api/v1/":  "http://localhost:8080/api/v1",
+		"https://dns.example.com/api/v1": "https://dns.example.com/api/v1",
+	}
+	for endpoint, expected := range tests {
+		client, err := newAPIClient(http.DefaultClient, Config{Token: "token", Endpoint: endpoint})
+		if err != nil {
+			t.Fatalf("endpoint %q: %s", endpoint, err)
+		}
+		if client.baseURL != expected {
+			t.Errorf("endpoint %q: expected base URL %s, got %s", endpoint, expected, client.baseURL)
+		}
+	}
+
+	for _, endpoint := range []string{"localhost:8080", "ftp://dns.example.com", "/api/v1"} {
+		if _, err := newAPIClient(http.DefaultClient, Config{Token: "token", Endpoint: endpoint}); err == nil {
+			t.Errorf("endpoint %q should be rejected", endpoint)
+		}
+	}
+}
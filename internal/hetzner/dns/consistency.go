@@ -0,0 +1,104 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/opsheaven/gohetznerdns"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/api"
+)
+
+// consistencyPollInterval is the wait between the reads checking whether a
+// write is visible.
+var consistencyPollInterval = 500 * time.Millisecond
+
+// consistencyWaiter polls after writes until they read back, so dependent
+// resources and data sources see the written values.
+type consistencyWaiter struct {
+	timeout time.Duration
+	cache   *responseCache
+}
+
+func newConsistencyWaiter(timeout time.Duration, cache *responseCache) *consistencyWaiter {
+	if timeout <= 0 {
+		return nil
+	}
+	return &consistencyWaiter{timeout: timeout, cache: cache}
+}
+
+// wait polls visible until it reports true. Stale cached responses with the
+// tags are dropped between the reads. A nil consistencyWaiter does not wait.
+func (w *consistencyWaiter) wait(ctx context.Context, description string, visible func(ctx context.Context) (bool, error), tags ...string) error {
+	if w == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+	for {
+		ok, err := visible(ctx)
+		if ctx.Err() != nil {
+			return fmt.Errorf("%s did not read back within %s", description, w.timeout)
+		} else if err != nil && !api.IsNotFound(err) {
+			return err
+		} else if ok {
+			return nil
+		}
+		w.cache.invalidate(tags...)
+
+		timer := time.NewTimer(consistencyPollInterval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%s did not read back within %s", description, w.timeout)
+		}
+	}
+}
+
+// waitForRecord waits until the zone records contain the written record.
+func (w *consistencyWaiter) waitForRecord(ctx context.Context, client *recordClient, written *gohetznerdns.Record) error {
+	if w == nil {
+		return nil
+	}
+	return w.wait(ctx, fmt.Sprintf("record %s", *written.Id), func(ctx context.Context) (bool, error) {
+		records, err := client.GetAllRecords(ctx, written.ZoneId)
+		if err != nil {
+			return false, err
+		}
+		for _, record := range records {
+			if equalString(record.Id, written.Id) {
+				return equalString(record.Type, written.Type) && equalString(record.Name, written.Name) &&
+					equalString(record.Value, written.Value) && (written.TTL == nil || equalInt(record.TTL, written.TTL)), nil
+			}
+		}
+		return false, nil
+	}, zoneTag(written.ZoneId))
+}
+
+// waitForZone waits until the zone list contains the written zone.
+func (w *consistencyWaiter) waitForZone(ctx context.Context, client *zoneClient, written *gohetznerdns.Zone) error {
+	if w == nil {
+		return nil
+	}
+	return w.wait(ctx, fmt.Sprintf("zone %s", *written.Name), func(ctx context.Context) (bool, error) {
+		zones, err := client.GetAllZonesByName(ctx, written.Name)
+		if err != nil {
+			return false, err
+		}
+		for _, zone := range zones {
+			if equalString(zone.Id, written.Id) {
+				return equalString(zone.Name, written.Name) && equalInt(zone.TTL, written.TTL), nil
+			}
+		}
+		return false, nil
+	}, zonesTag, zoneTag(written.Id))
+}
+
+func equalString(a, b *string) bool {
+	return a == b || (a != nil && b != nil && *a == *b)
+}
+
+func equalInt(a, b *int) bool {
+	return a == b || (a != nil && b != nil && *a == *b)
+}
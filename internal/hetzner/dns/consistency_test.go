@@ -0,0 +1,72 @@
+package dns
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/dns/dnstest"
+)
+
+func newTestConsistentServices(t *testing.T, timeout time.Duration) (DNSServices, *dnstest.Server) {
+	t.Helper()
+	interval := consistencyPollInterval
+	consistencyPollInterval = time.Millisecond
+	t.Cleanup(func() { consistencyPollInterval = interval })
+
+	server := dnstest.NewServer(t)
+	services, diags := NewClient(Config{Token: dnstest.Token, Endpoint: server.URL, CacheTTL: time.Minute, ConsistencyTimeout: timeout}, http.DefaultClient)
+	if diags.HasError() {
+		t.Fatalf("client initialization failed: %v", diags)
+	}
+	return services, server
+}
+
+func TestRecordServiceWaitsUntilRecordReadsBack(t *testing.T) {
+	services, server := newTestConsistentServices(t, time.Minute)
+	zone := server.AddZone("example.com", 3600)
+	server.DelayRecordVisibility(3)
+
+	ctx := context.Background()
+	record := newTestRecord(zone.Id, "A", "www", "192.0.2.1", 300)
+	if diags := services.RecordService().Create(ctx, record); diags.HasError() {
+		t.Fatalf("create failed: %v", diags)
+	}
+	records := &Records{ZoneId: types.StringValue(zone.Id), OwnerFilter: types.StringNull()}
+	if diags := services.RecordService().List(ctx, records); diags.HasError() || len(records.Records) != 5 {
+		t.Errorf("created record should be listed, got %d records: %v", len(records.Records), diags)
+	}
+}
+
+func TestRecordServiceReportsInconsistentReads(t *testing.T) {
+	services, server := newTestConsistentServices(t, 20*time.Millisecond)
+	zone := server.AddZone("example.com", 3600)
+	server.DelayRecordVisibility(1000)
+
+	record := newTestRecord(zone.Id, "A", "www", "192.0.2.1", 300)
+	diags := services.RecordService().Create(context.Background(), record)
+	if !diags.HasError() {
+		t.Fatal("record not reading back should be reported")
+	}
+	if record.Id.IsUnknown() || record.Id.IsNull() {
+		t.Error("created record should be tracked")
+	}
+}
+
+func TestZoneServiceWaitsUntilZoneReadsBack(t *testing.T) {
+	services, server := newTestConsistentServices(t, time.Minute)
+	zone := &ZoneResource{Zone: Zone{Id: types.StringNull(), Name: NewDomainNameValue("example.com"), TTL: types.Int64Value(3600)}, CopyRecordsFromZoneId: types.StringNull()}
+	if diags := services.ZoneService().Create(context.Background(), zone); diags.HasError() {
+		t.Fatalf("create failed: %v", diags)
+	}
+	zone.TTL = types.Int64Value(7200)
+	if diags := services.ZoneService().Update(context.Background(), &zone.Zone); diags.HasError() {
+		t.Fatalf("update failed: %v", diags)
+	}
+	// Each write is followed by a single read of the zone list.
+	if requests := server.Requests(); requests != 4 {
+		t.Errorf("expected 4 requests, got %d", requests)
+	}
+}
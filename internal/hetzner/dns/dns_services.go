@@ -1,18 +1,26 @@
 package dns
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/opsheaven/gohetznerdns"
 )
 
 type DNSServices interface {
 	ZoneService() ZoneService
 	RecordService() RecordService
+	ReverseRecordService() ReverseRecordService
+	ZoneImportService() ZoneImportService
+	AccountService() AccountService
 }
 
 type dnsServicesImpl struct {
-	recordService RecordService
-	zoneService   ZoneService
+	recordService        RecordService
+	zoneService          ZoneService
+	reverseRecordService ReverseRecordService
+	zoneImportService    ZoneImportService
+	accountService       AccountService
 }
 
 var _ DNSServices = &dnsServicesImpl{}
@@ -25,18 +33,55 @@ func (d *dnsServicesImpl) ZoneService() ZoneService {
 	return d.zoneService
 }
 
-func NewClient(dnsApiToken string) (DNSServices, diag.Diagnostics) {
+func (d *dnsServicesImpl) ReverseRecordService() ReverseRecordService {
+	return d.reverseRecordService
+}
+
+func (d *dnsServicesImpl) ZoneImportService() ZoneImportService {
+	return d.zoneImportService
+}
+
+func (d *dnsServicesImpl) AccountService() AccountService {
+	return d.accountService
+}
+
+// Config configures the Hetzner DNS API client.
+type Config struct {
+	Token string
+	// TokenSource describes where the token was configured, for error messages.
+	TokenSource string
+	// Endpoint is the base URL of the API, DefaultEndpoint when empty.
+	Endpoint string
+	// CacheTTL caches the responses of read requests, 0 disables the cache.
+	CacheTTL time.Duration
+	// SerializeZoneWrites orders the record writes within a zone.
+	SerializeZoneWrites bool
+	// ConsistencyTimeout waits up to the duration after creates and updates
+	// until the written values read back, 0 disables waiting.
+	ConsistencyTimeout time.Duration
+}
+
+func NewClient(config Config, httpClient *http.Client) (DNSServices, diag.Diagnostics) {
 	diagnostics := diag.Diagnostics{}
 
-	dnsClient, err := gohetznerdns.NewClient(dnsApiToken)
+	dnsClient, err := newAPIClient(httpClient, config)
 	if err != nil {
 		diagnostics.AddError("DNS Client Initialization Error", err.Error())
 	}
 	if diagnostics.HasError() {
 		return nil, diagnostics
 	}
+	records := &recordClient{client: dnsClient}
+	zones := &zoneClient{client: dnsClient}
+	locks := newZoneLocks(config.SerializeZoneWrites)
+	reverseRecordService := newReverseRecordService(records, zones, locks)
+	consistency := newConsistencyWaiter(config.ConsistencyTimeout, dnsClient.cache)
+	recordService := newRecordService(records, reverseRecordService, locks, consistency)
 	return &dnsServicesImpl{
-		recordService: newRecordService(dnsClient.GetRecordService()),
-		zoneService:   newZoneService(dnsClient.GetZoneService()),
+		recordService:        recordService,
+		zoneService:          newZoneService(zones, recordService, records, consistency),
+		reverseRecordService: reverseRecordService,
+		zoneImportService:    newZoneImportService(recordService),
+		accountService:       newAccountService(zones),
 	}, diagnostics
 }
@@ -0,0 +1,65 @@
+package dnstest
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	miekgdns "github.com/miekg/dns"
+)
+
+// TSIGKeyName is the key name zone transfers of a StartAXFRServer server are
+// signed with.
+const TSIGKeyName = "transfer."
+
+// StartAXFRServer serves the resource records of a zone over TCP for zone
+// transfers and returns its address. The first record must be the SOA record.
+// With a non-empty tsigSecret, unsigned transfers are refused.
+func StartAXFRServer(t testing.TB, zoneName string, records []string, tsigSecret string) string {
+	t.Helper()
+	rrs := []miekgdns.RR{}
+	for _, record := range records {
+		rr, err := miekgdns.NewRR(record)
+		if err != nil {
+			t.Fatalf("invalid test record %s: %s", record, err)
+		}
+		rrs = append(rrs, rr)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := miekgdns.NewServeMux()
+	mux.HandleFunc(miekgdns.Fqdn(zoneName), func(w miekgdns.ResponseWriter, req *miekgdns.Msg) {
+		if tsigSecret != "" && (req.IsTsig() == nil || w.TsigStatus() != nil) {
+			m := new(miekgdns.Msg)
+			m.SetRcode(req, miekgdns.RcodeNotAuth)
+			w.WriteMsg(m)
+			return
+		}
+		ch := make(chan *miekgdns.Envelope)
+		transfer := new(miekgdns.Transfer)
+		go func() {
+			ch <- &miekgdns.Envelope{RR: append(rrs, rrs[0])}
+			close(ch)
+		}()
+		transfer.Out(w, req, ch)
+		w.Hijack()
+	})
+	server := &miekgdns.Server{Listener: listener, Handler: mux}
+	if tsigSecret != "" {
+		server.TsigSecret = map[string]string{TSIGKeyName: tsigSecret}
+	}
+	started := make(chan struct{})
+	server.NotifyStartedFunc = func() { close(started) }
+	go server.ActivateAndServe()
+	t.Cleanup(func() { server.Shutdown() })
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("AXFR server did not start")
+	}
+	return listener.Addr().String()
+}
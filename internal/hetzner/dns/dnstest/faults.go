@@ -0,0 +1,102 @@
+package dnstest
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Fault scripts a failure of the requests matching Method and Path.
+type Fault struct {
+	// Method matches any method when empty.
+	Method string
+	// Path is a prefix of the request path without the /api/v1 prefix, e.g.
+	// /records. Matches any path when empty.
+	Path string
+	// Call is the first matching request failing, counting from 1. Defaults
+	// to the next matching request.
+	Call int
+	// Times is the number of failing requests from Call on, defaults to 1.
+	// Negative values fail all requests from Call on.
+	Times int
+
+	// Latency delays the request before it is handled or failed.
+	Latency time.Duration
+	// Drop closes the connection without a response.
+	Drop bool
+	// StatusCode responds with an error of this status code.
+	StatusCode int
+	// RetryAfter is sent as the Retry-After header of the error response.
+	RetryAfter string
+}
+
+type fault struct {
+	Fault
+	calls  int
+	failed int
+}
+
+// InjectFault adds a scripted fault. Faults are evaluated in the order they
+// were added, the first active matching fault is applied.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if f.Call < 1 {
+		f.Call = 1
+	}
+	if f.Times == 0 {
+		f.Times = 1
+	}
+	s.faults = append(s.faults, &fault{Fault: f})
+}
+
+// RateLimit responds to the next n requests with 429 Too Many Requests and
+// Retry-After 0.
+func (s *Server) RateLimit(n int) {
+	s.InjectFault(Fault{Times: n, StatusCode: http.StatusTooManyRequests, RetryAfter: "0"})
+}
+
+func (s *Server) matchFault(r *http.Request) *fault {
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1")
+	var matched *fault
+	for _, f := range s.faults {
+		if (f.Method != "" && f.Method != r.Method) || !strings.HasPrefix(path, f.Path) {
+			continue
+		}
+		f.calls++
+		if matched == nil && f.calls >= f.Call && (f.Times < 0 || f.failed < f.Times) {
+			f.failed++
+			matched = f
+		}
+	}
+	return matched
+}
+
+// apply injects the fault and reports whether the request should still be
+// handled.
+func (f *fault) apply(w http.ResponseWriter, r *http.Request) bool {
+	if f.Latency > 0 {
+		select {
+		case <-time.After(f.Latency):
+		case <-r.Context().Done():
+			return false
+		}
+	}
+	if f.Drop {
+		if hijacker, ok := w.(http.Hijacker); ok {
+			if conn, _, err := hijacker.Hijack(); err == nil {
+				conn.Close()
+				return false
+			}
+		}
+		panic(http.ErrAbortHandler)
+	}
+	if f.StatusCode != 0 {
+		if f.RetryAfter != "" {
+			w.Header().Set("Retry-After", f.RetryAfter)
+		}
+		writeError(w, f.StatusCode, http.StatusText(f.StatusCode), nil)
+		return false
+	}
+	return true
+}
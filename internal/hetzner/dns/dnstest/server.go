@@ -0,0 +1,509 @@
+// Package dnstest provides an in-memory fake of the Hetzner DNS Public API
+// [https://dns.hetzner.com/api-docs] for tests running without network access.
+package dnstest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	Token          = "dnstest-token"
+	defaultTTL     = 86400
+	defaultPerPage = 100
+)
+
+var Nameservers = []string{"hydrogen.ns.hetzner.com.", "oxygen.ns.hetzner.com.", "helium.ns.hetzner.de."}
+
+var recordTypes = []string{"A", "AAAA", "NS", "MX", "CNAME", "RP", "TXT", "SOA", "PTR", "HINFO", "SRV", "DANE", "TLSA", "DS", "CAA"}
+
+type Zone struct {
+	Id           string   `json:"id"`
+	Name         string   `json:"name"`
+	TTL          int      `json:"ttl"`
+	NS           []string `json:"ns"`
+	Paused       bool     `json:"paused"`
+	Status       string   `json:"status"`
+	RecordsCount int      `json:"records_count"`
+}
+
+type Record struct {
+	Id     string `json:"id"`
+	Type   string `json:"type"`
+	ZoneId string `json:"zone_id"`
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	TTL    *int   `json:"ttl,omitempty"`
+}
+
+type fieldError struct {
+	Name     string   `json:"name"`
+	Messages []string `json:"messages"`
+}
+
+// Server is a stateful fake of the Hetzner DNS API. New zones get the SOA and
+// NS records Hetzner creates, requests need the Auth-API-Token Token and lists
+// are paginated like the real API.
+type Server struct {
+	URL string
+
+	server   *httptest.Server
+	mu       sync.Mutex
+	zones    []*Zone
+	records  []*Record
+	nextId   int
+	requests int
+	faults   []*fault
+	header   http.Header
+
+	visibilityDelay int
+	hidden          map[string]int
+}
+
+// NewServer starts a server which is closed when the test finishes.
+func NewServer(t testing.TB) *Server {
+	s := &Server{}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+// AddZone creates a zone as if it was created through the API.
+func (s *Server) AddZone(name string, ttl int) Zone {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.createZone(name, ttl)
+}
+
+// AddRecord creates a record in the zone as if it was created through the API.
+func (s *Server) AddRecord(zoneId, recordType, name, value string, ttl *int) Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.createRecord(zoneId, recordType, name, value, ttl)
+}
+
+// Zones returns a snapshot of all zones.
+func (s *Server) Zones() []Zone {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	zones := []Zone{}
+	for _, zone := range s.zones {
+		zones = append(zones, *zone)
+	}
+	return zones
+}
+
+// Records returns a snapshot of the records of a zone.
+func (s *Server) Records(zoneId string) []Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	records := []Record{}
+	for _, record := range s.records {
+		if record.ZoneId == zoneId {
+			records = append(records, *record)
+		}
+	}
+	return records
+}
+
+// SetHeader sets a header sent with all responses, e.g. rate limit headers.
+func (s *Server) SetHeader(key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.header == nil {
+		s.header = http.Header{}
+	}
+	s.header.Set(key, value)
+}
+
+// DelayRecordVisibility hides records created through the API from the next
+// reads record list requests, like an eventually consistent API.
+func (s *Server) DelayRecordVisibility(reads int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.visibilityDelay = reads
+}
+
+// Requests returns the number of requests received.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests++
+	fault := s.matchFault(r)
+	s.mu.Unlock()
+
+	if fault != nil && !fault.apply(w, r) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, values := range s.header {
+		w.Header()[key] = values
+	}
+	if r.Header.Get("Auth-API-Token") != Token {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"message": "Invalid authentication credentials"})
+		return
+	}
+
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v1"), "/"), "/")
+	id := ""
+	if len(segments) == 2 {
+		id = segments[1]
+	} else if len(segments) != 1 {
+		writeError(w, http.StatusNotFound, "not found", nil)
+		return
+	}
+
+	switch {
+	case segments[0] == "zones" && id == "" && r.Method == http.MethodGet:
+		s.listZones(w, r)
+	case segments[0] == "zones" && id == "" && r.Method == http.MethodPost:
+		s.postZone(w, r)
+	case segments[0] == "zones" && id != "":
+		s.handleZone(w, r, id)
+	case segments[0] == "records" && id == "" && r.Method == http.MethodGet:
+		s.listRecords(w, r)
+	case segments[0] == "records" && id == "" && r.Method == http.MethodPost:
+		s.postRecord(w, r)
+	case segments[0] == "records" && id == "bulk" && r.Method == http.MethodPost:
+		s.postRecords(w, r)
+	case segments[0] == "records" && id != "":
+		s.handleRecord(w, r, id)
+	default:
+		writeError(w, http.StatusNotFound, "not found", nil)
+	}
+}
+
+func (s *Server) listZones(w http.ResponseWriter, r *http.Request) {
+	search := r.URL.Query().Get("search_name")
+	name := r.URL.Query().Get("name")
+	zones := []*Zone{}
+	for _, zone := range s.zones {
+		if (search == "" || strings.Contains(zone.Name, search)) && (name == "" || zone.Name == name) {
+			zones = append(zones, zone)
+		}
+	}
+	page, meta, ok := paginate(w, r, zones)
+	if ok {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"zones": page, "meta": meta})
+	}
+}
+
+func (s *Server) postZone(w http.ResponseWriter, r *http.Request) {
+	request := struct {
+		Name string `json:"name"`
+		TTL  *int   `json:"ttl"`
+	}{}
+	if !decode(w, r, &request) {
+		return
+	}
+	if fields := validateZone(request.Name, request.TTL); len(fields) > 0 {
+		writeError(w, http.StatusUnprocessableEntity, "invalid zone", fields)
+		return
+	}
+	if s.zoneByName(request.Name) != nil {
+		writeError(w, http.StatusConflict, fmt.Sprintf("zone %s already exists", request.Name), nil)
+		return
+	}
+	ttl := defaultTTL
+	if request.TTL != nil {
+		ttl = *request.TTL
+	}
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"zone": s.createZone(request.Name, ttl)})
+}
+
+func (s *Server) handleZone(w http.ResponseWriter, r *http.Request, id string) {
+	zone := s.zoneById(id)
+	if zone == nil {
+		writeError(w, http.StatusNotFound, "zone not found", nil)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"zone": zone})
+	case http.MethodPut:
+		request := struct {
+			Name string `json:"name"`
+			TTL  *int   `json:"ttl"`
+		}{}
+		if !decode(w, r, &request) {
+			return
+		}
+		if fields := validateZone(request.Name, request.TTL); len(fields) > 0 {
+			writeError(w, http.StatusUnprocessableEntity, "invalid zone", fields)
+			return
+		}
+		if request.Name != zone.Name {
+			writeError(w, http.StatusUnprocessableEntity, "zone name cannot be changed", []fieldError{{Name: "name", Messages: []string{"cannot be changed"}}})
+			return
+		}
+		if request.TTL != nil {
+			zone.TTL = *request.TTL
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"zone": zone})
+	case http.MethodDelete:
+		s.zones = slices.DeleteFunc(s.zones, func(z *Zone) bool { return z.Id == id })
+		s.records = slices.DeleteFunc(s.records, func(r *Record) bool { return r.ZoneId == id })
+		w.WriteHeader(http.StatusOK)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed", nil)
+	}
+}
+
+func (s *Server) listRecords(w http.ResponseWriter, r *http.Request) {
+	zoneId := r.URL.Query().Get("zone_id")
+	records := []*Record{}
+	for _, record := range s.records {
+		if (zoneId == "" || record.ZoneId == zoneId) && s.hidden[record.Id] == 0 {
+			records = append(records, record)
+		}
+	}
+	for id, reads := range s.hidden {
+		if reads > 0 {
+			s.hidden[id]--
+		}
+	}
+	page, meta, ok := paginate(w, r, records)
+	if ok {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"records": page, "meta": meta})
+	}
+}
+
+func (s *Server) postRecord(w http.ResponseWriter, r *http.Request) {
+	request := Record{}
+	if !decode(w, r, &request) {
+		return
+	}
+	if fields := s.validateRecord(request); len(fields) > 0 {
+		writeError(w, http.StatusUnprocessableEntity, "invalid record", fields)
+		return
+	}
+	record := s.createRecord(request.ZoneId, request.Type, request.Name, request.Value, request.TTL)
+	s.delayVisibility(record)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"record": record})
+}
+
+// postRecords creates the valid records of a bulk request and returns the
+// rejected ones as invalid records, like the real API.
+func (s *Server) postRecords(w http.ResponseWriter, r *http.Request) {
+	request := struct {
+		Records []Record `json:"records"`
+	}{}
+	if !decode(w, r, &request) {
+		return
+	}
+	records, valid, invalid := []*Record{}, []Record{}, []Record{}
+	for _, record := range request.Records {
+		if fields := s.validateRecord(record); len(fields) > 0 {
+			invalid = append(invalid, record)
+			continue
+		}
+		valid = append(valid, record)
+		created := s.createRecord(record.ZoneId, record.Type, record.Name, record.Value, record.TTL)
+		s.delayVisibility(created)
+		records = append(records, created)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"records": records, "valid_records": valid, "invalid_records": invalid})
+}
+
+func (s *Server) handleRecord(w http.ResponseWriter, r *http.Request, id string) {
+	record := s.recordById(id)
+	if record == nil {
+		writeError(w, http.StatusNotFound, "record not found", nil)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"record": record})
+	case http.MethodPut:
+		request := Record{}
+		if !decode(w, r, &request) {
+			return
+		}
+		if fields := s.validateRecord(request); len(fields) > 0 {
+			writeError(w, http.StatusUnprocessableEntity, "invalid record", fields)
+			return
+		}
+		s.zoneById(record.ZoneId).RecordsCount--
+		s.zoneById(request.ZoneId).RecordsCount++
+		record.Type, record.ZoneId, record.Name, record.Value, record.TTL = request.Type, request.ZoneId, request.Name, request.Value, request.TTL
+		writeJSON(w, http.StatusOK, map[string]interface{}{"record": record})
+	case http.MethodDelete:
+		if zone := s.zoneById(record.ZoneId); zone != nil {
+			zone.RecordsCount--
+		}
+		s.records = slices.DeleteFunc(s.records, func(r *Record) bool { return r.Id == id })
+		w.WriteHeader(http.StatusOK)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed", nil)
+	}
+}
+
+func (s *Server) createZone(name string, ttl int) *Zone {
+	zone := &Zone{
+		Id:     s.newId(),
+		Name:   name,
+		TTL:    ttl,
+		NS:     slices.Clone(Nameservers),
+		Status: "verified",
+	}
+	s.zones = append(s.zones, zone)
+	s.createRecord(zone.Id, "SOA", "@", fmt.Sprintf("%s dns.hetzner.com. 2024010100 86400 10800 3600000 3600", Nameservers[0]), nil)
+	for _, nameserver := range Nameservers {
+		s.createRecord(zone.Id, "NS", "@", nameserver, nil)
+	}
+	return zone
+}
+
+func (s *Server) createRecord(zoneId, recordType, name, value string, ttl *int) *Record {
+	record := &Record{Id: s.newId(), Type: recordType, ZoneId: zoneId, Name: name, Value: value, TTL: ttl}
+	s.records = append(s.records, record)
+	if zone := s.zoneById(zoneId); zone != nil {
+		zone.RecordsCount++
+	}
+	return record
+}
+
+// delayVisibility hides a created record from lists as set up by
+// DelayRecordVisibility.
+func (s *Server) delayVisibility(record *Record) {
+	if s.visibilityDelay > 0 {
+		if s.hidden == nil {
+			s.hidden = map[string]int{}
+		}
+		s.hidden[record.Id] = s.visibilityDelay
+	}
+}
+
+func (s *Server) newId() string {
+	s.nextId++
+	return fmt.Sprintf("%032x", s.nextId)
+}
+
+func (s *Server) zoneById(id string) *Zone {
+	for _, zone := range s.zones {
+		if zone.Id == id {
+			return zone
+		}
+	}
+	return nil
+}
+
+func (s *Server) zoneByName(name string) *Zone {
+	for _, zone := range s.zones {
+		if zone.Name == name {
+			return zone
+		}
+	}
+	return nil
+}
+
+func (s *Server) recordById(id string) *Record {
+	for _, record := range s.records {
+		if record.Id == id {
+			return record
+		}
+	}
+	return nil
+}
+
+func validateZone(name string, ttl *int) []fieldError {
+	fields := []fieldError{}
+	if !strings.Contains(strings.Trim(name, "."), ".") {
+		fields = append(fields, fieldError{Name: "name", Messages: []string{"must be a valid domain name"}})
+	}
+	if ttl != nil && *ttl < 0 {
+		fields = append(fields, fieldError{Name: "ttl", Messages: []string{"must not be negative"}})
+	}
+	return fields
+}
+
+func (s *Server) validateRecord(record Record) []fieldError {
+	fields := []fieldError{}
+	if s.zoneById(record.ZoneId) == nil {
+		fields = append(fields, fieldError{Name: "zone_id", Messages: []string{"zone not found"}})
+	}
+	if !slices.Contains(recordTypes, record.Type) {
+		fields = append(fields, fieldError{Name: "type", Messages: []string{"is not supported"}})
+	}
+	if record.Name == "" {
+		fields = append(fields, fieldError{Name: "name", Messages: []string{"is required"}})
+	}
+	if record.Value == "" {
+		fields = append(fields, fieldError{Name: "value", Messages: []string{"is required"}})
+	}
+	if record.TTL != nil && *record.TTL < 0 {
+		fields = append(fields, fieldError{Name: "ttl", Messages: []string{"must not be negative"}})
+	}
+	return fields
+}
+
+func paginate[T any](w http.ResponseWriter, r *http.Request, items []T) ([]T, map[string]interface{}, bool) {
+	page, perPage := 1, defaultPerPage
+	var err error
+	if value := r.URL.Query().Get("page"); value != "" {
+		if page, err = strconv.Atoi(value); err != nil || page < 1 {
+			writeError(w, http.StatusBadRequest, "invalid page", []fieldError{{Name: "page", Messages: []string{"must be a positive number"}}})
+			return nil, nil, false
+		}
+	}
+	if value := r.URL.Query().Get("per_page"); value != "" {
+		if perPage, err = strconv.Atoi(value); err != nil || perPage < 1 || perPage > defaultPerPage {
+			writeError(w, http.StatusBadRequest, "invalid per_page", []fieldError{{Name: "per_page", Messages: []string{"must be between 1 and 100"}}})
+			return nil, nil, false
+		}
+	}
+
+	lastPage := max(1, (len(items)+perPage-1)/perPage)
+	start := min((page-1)*perPage, len(items))
+	end := min(start+perPage, len(items))
+	pagination := map[string]interface{}{
+		"page":          page,
+		"per_page":      perPage,
+		"last_page":     lastPage,
+		"total_entries": len(items),
+	}
+	if page > 1 {
+		pagination["previous_page"] = page - 1
+	}
+	if page < lastPage {
+		pagination["next_page"] = page + 1
+	}
+	return items[start:end], map[string]interface{}{"pagination": pagination}, true
+}
+
+func decode(w http.ResponseWriter, r *http.Request, value interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(value); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err), nil)
+		return false
+	}
+	return true
+}
+
+func writeError(w http.ResponseWriter, statusCode int, message string, fields []fieldError) {
+	body := map[string]interface{}{"message": message, "code": statusCode}
+	if len(fields) > 0 {
+		body["details"] = map[string]interface{}{"fields": fields}
+	}
+	writeJSON(w, statusCode, map[string]interface{}{"error": body})
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,167 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"golang.org/x/net/idna"
+)
+
+// idnProfile converts internationalized names to punycode and back.
+// Record names such as `@`, `*` or `_dmarc` are not valid host names,
+// so strict domain name validation is disabled.
+var idnProfile = idna.New(
+	idna.MapForLookup(),
+	idna.Transitional(false),
+	idna.StrictDomainName(false),
+	idna.VerifyDNSLength(false),
+)
+
+func toASCIIName(name string) (string, error) {
+	return idnProfile.ToASCII(name)
+}
+
+func toUnicodeName(name string) string {
+	unicode, err := idnProfile.ToUnicode(name)
+	if err != nil {
+		return name
+	}
+	return unicode
+}
+
+// DomainNameType is a string type for zone and record names which accepts
+// both unicode and punycode representations of the same name.
+type DomainNameType struct {
+	basetypes.StringType
+}
+
+var _ basetypes.StringTypable = DomainNameType{}
+
+func (t DomainNameType) Equal(o attr.Type) bool {
+	other, ok := o.(DomainNameType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t DomainNameType) String() string {
+	return "DomainNameType"
+}
+
+func (t DomainNameType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return DomainName{StringValue: in}, nil
+}
+
+func (t DomainNameType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+	return stringValuable, nil
+}
+
+func (t DomainNameType) ValueType(_ context.Context) attr.Value {
+	return DomainName{}
+}
+
+// DomainName holds a zone or record name as configured by the user.
+// Unicode and punycode forms of the same name are semantically equal.
+type DomainName struct {
+	basetypes.StringValue
+}
+
+var _ basetypes.StringValuableWithSemanticEquals = DomainName{}
+
+func NewDomainNameValue(value string) DomainName {
+	return DomainName{StringValue: types.StringValue(value)}
+}
+
+func (v DomainName) Equal(o attr.Value) bool {
+	other, ok := o.(DomainName)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v DomainName) Type(_ context.Context) attr.Type {
+	return DomainNameType{}
+}
+
+func (v DomainName) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+	newValue, ok := newValuable.(DomainName)
+	if !ok {
+		diagnostics.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+		return false, diagnostics
+	}
+	prior, err := toASCIIName(v.ValueString())
+	if err != nil {
+		return false, diagnostics
+	}
+	current, err := toASCIIName(newValue.ValueString())
+	if err != nil {
+		return false, diagnostics
+	}
+	return prior == current, diagnostics
+}
+
+// ASCIIValue returns the punycode representation used by the Hetzner API.
+func (v DomainName) ASCIIValue() (string, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+	name, err := toASCIIName(v.ValueString())
+	if err != nil {
+		diagnostics.AddError("Invalid Domain Name", fmt.Sprintf("%s can not be converted to punycode: %s", v.String(), err.Error()))
+	}
+	return name, diagnostics
+}
+
+// UnicodeValue returns the unicode representation of the name.
+func (v DomainName) UnicodeValue() types.String {
+	if v.IsNull() || v.IsUnknown() {
+		return v.StringValue
+	}
+	return types.StringValue(toUnicodeName(v.ValueString()))
+}
+
+// unicodeNameFromName plans `name_unicode` from the planned `name`
+// so that the effective value is visible before apply.
+type unicodeNameFromName struct{}
+
+var _ planmodifier.String = unicodeNameFromName{}
+
+func (m unicodeNameFromName) Description(_ context.Context) string {
+	return "Computes the unicode representation of the planned name."
+}
+
+func (m unicodeNameFromName) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m unicodeNameFromName) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	var name DomainName
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	if resp.Diagnostics.HasError() || name.IsUnknown() || name.IsNull() {
+		return
+	}
+	resp.PlanValue = name.UnicodeValue()
+}
@@ -0,0 +1,90 @@
+package dns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDomainNameConversion(t *testing.T) {
+	tests := []struct {
+		name    string
+		ascii   string
+		unicode string
+	}{
+		{name: "example.com", ascii: "example.com", unicode: "example.com"},
+		{name: "bücher.example", ascii: "xn--bcher-kva.example", unicode: "bücher.example"},
+		{name: "xn--bcher-kva.example", ascii: "xn--bcher-kva.example", unicode: "bücher.example"},
+		{name: "BÜCHER.Example", ascii: "xn--bcher-kva.example", unicode: "bücher.example"},
+		{name: "bücher.example.", ascii: "xn--bcher-kva.example.", unicode: "bücher.example."},
+		{name: "xn--bcher-kva.example.", ascii: "xn--bcher-kva.example.", unicode: "bücher.example."},
+		{name: "@", ascii: "@", unicode: "@"},
+		{name: "*", ascii: "*", unicode: "*"},
+		{name: "_dmarc", ascii: "_dmarc", unicode: "_dmarc"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			name := NewDomainNameValue(test.name)
+			ascii, diags := name.ASCIIValue()
+			if diags.HasError() || ascii != test.ascii {
+				t.Errorf("expected punycode %q, got %q: %v", test.ascii, ascii, diags)
+			}
+			if unicode := name.UnicodeValue().ValueString(); unicode != test.unicode {
+				t.Errorf("expected unicode %q, got %q", test.unicode, unicode)
+			}
+			// Both representations convert back to the same punycode.
+			if roundTrip, _ := NewDomainNameValue(test.unicode).ASCIIValue(); roundTrip != test.ascii {
+				t.Errorf("expected round trip to %q, got %q", test.ascii, roundTrip)
+			}
+		})
+	}
+}
+
+func TestDomainNameRejectsInvalidLabels(t *testing.T) {
+	for _, name := range []string{"xn--zz.example", "xn--a", "-invalid-.example", "\u0080.example"} {
+		t.Run(name, func(t *testing.T) {
+			if _, diags := NewDomainNameValue(name).ASCIIValue(); !diags.HasError() {
+				t.Errorf("expected invalid domain name %q", name)
+			}
+			// Invalid names are shown as configured.
+			if unicode := NewDomainNameValue(name).UnicodeValue().ValueString(); unicode != name {
+				t.Errorf("expected unicode %q, got %q", name, unicode)
+			}
+		})
+	}
+}
+
+func TestDomainNameSemanticEquals(t *testing.T) {
+	tests := []struct {
+		prior, current string
+		equal          bool
+	}{
+		{prior: "bücher.example", current: "xn--bcher-kva.example", equal: true},
+		{prior: "xn--bcher-kva.example", current: "bücher.example", equal: true},
+		{prior: "Bücher.Example", current: "bücher.example", equal: true},
+		{prior: "bücher.example.", current: "xn--bcher-kva.example.", equal: true},
+		{prior: "bücher.example.", current: "bücher.example", equal: false},
+		{prior: "bücher.example", current: "bucher.example", equal: false},
+		{prior: "xn--zz.example", current: "xn--zz.example", equal: false},
+	}
+	for _, test := range tests {
+		equal, diags := NewDomainNameValue(test.prior).StringSemanticEquals(context.Background(), NewDomainNameValue(test.current))
+		if diags.HasError() || equal != test.equal {
+			t.Errorf("%q and %q: expected equal %t, got %t: %v", test.prior, test.current, test.equal, equal, diags)
+		}
+	}
+
+	if _, diags := NewDomainNameValue("example.com").StringSemanticEquals(context.Background(), types.StringValue("example.com")); !diags.HasError() {
+		t.Error("values of other types should fail")
+	}
+}
+
+func TestDomainNameUnicodeValueKeepsNullAndUnknown(t *testing.T) {
+	if value := (DomainName{StringValue: types.StringNull()}).UnicodeValue(); !value.IsNull() {
+		t.Errorf("expected null, got %v", value)
+	}
+	if value := (DomainName{StringValue: types.StringUnknown()}).UnicodeValue(); !value.IsUnknown() {
+		t.Errorf("expected unknown, got %v", value)
+	}
+}
@@ -0,0 +1,150 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/opsheaven/gohetznerdns"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/api"
+)
+
+// Ownership of a record is persisted as a sibling TXT record, similar to the
+// external-dns TXT registry. The TXT record is named after the record type and
+// name so that it never collides with CNAME records, and its value references
+// the owned record by identifier.
+const (
+	ownershipPrefix    = "_tf-owner-"
+	ownershipHeritage  = "heritage=terraform"
+	ownershipOwnerKey  = "terraform/owner="
+	ownershipRecordKey = "terraform/record="
+)
+
+type ownership struct {
+	owner    string
+	recordId string
+}
+
+func ownershipRecordName(recordType, name string) string {
+	prefix := ownershipPrefix + strings.ToLower(recordType)
+	name = strings.TrimPrefix(strings.TrimPrefix(name, "*"), ".")
+	if name == "@" || name == "" {
+		return prefix
+	}
+	return prefix + "." + name
+}
+
+func ownershipRecordValue(owner, recordId string) string {
+	return fmt.Sprintf("\"%s,%s%s,%s%s\"", ownershipHeritage, ownershipOwnerKey, owner, ownershipRecordKey, recordId)
+}
+
+func parseOwnershipRecord(record *gohetznerdns.Record) (*ownership, bool) {
+	if record.Type == nil || *record.Type != "TXT" || record.Value == nil {
+		return nil, false
+	}
+	fields := strings.Split(strings.Trim(*record.Value, "\""), ",")
+	if len(fields) != 3 || fields[0] != ownershipHeritage ||
+		!strings.HasPrefix(fields[1], ownershipOwnerKey) || !strings.HasPrefix(fields[2], ownershipRecordKey) {
+		return nil, false
+	}
+	return &ownership{
+		owner:    strings.TrimPrefix(fields[1], ownershipOwnerKey),
+		recordId: strings.TrimPrefix(fields[2], ownershipRecordKey),
+	}, true
+}
+
+// applyOwnership populates the owner of listed records from their ownership
+// records and drops records not owned by the owner filter, if given.
+func (r *Records) applyOwnership(hetznerRecords []*gohetznerdns.Record) {
+	owners := map[string]*gohetznerdns.Record{}
+	for _, hetznerRecord := range hetznerRecords {
+		if o, ok := parseOwnershipRecord(hetznerRecord); ok {
+			owners[o.recordId] = hetznerRecord
+		}
+	}
+
+	records := []Record{}
+	for _, record := range r.Records {
+		record.Owner = types.StringNull()
+		record.OwnerRecordId = types.StringNull()
+		if ownershipRecord, ok := owners[record.Id.ValueString()]; ok {
+			o, _ := parseOwnershipRecord(ownershipRecord)
+			record.Owner = types.StringValue(o.owner)
+			record.OwnerRecordId = types.StringValue(*ownershipRecord.Id)
+		}
+		if !r.OwnerFilter.IsNull() && record.Owner.ValueString() != r.OwnerFilter.ValueString() {
+			continue
+		}
+		records = append(records, record)
+	}
+	r.Records = records
+}
+
+// readOwnership refreshes the owner of the record from its ownership record,
+// so that ownership records deleted or edited outside of Terraform are
+// detected.
+func (s *recordServiceImpl) readOwnership(ctx context.Context, record *Record) diag.Diagnostics {
+	diagnostics := diag.Diagnostics{}
+	if record.OwnerRecordId.IsNull() || record.OwnerRecordId.IsUnknown() {
+		return diagnostics
+	}
+	ownershipRecord, err := s.client.GetRecord(ctx, record.OwnerRecordId.ValueStringPointer())
+	if api.IsNotFound(err) {
+		record.Owner = types.StringNull()
+		record.OwnerRecordId = types.StringNull()
+		return diagnostics
+	} else if err != nil {
+		api.AddError(&diagnostics, err)
+		return diagnostics
+	}
+	record.Owner = types.StringNull()
+	if o, ok := parseOwnershipRecord(ownershipRecord); ok && o.recordId == record.Id.ValueString() {
+		record.Owner = types.StringValue(o.owner)
+	}
+	return diagnostics
+}
+
+// syncOwnership creates, updates or deletes the ownership record of the record
+// according to its `owner` attribute.
+func (s *recordServiceImpl) syncOwnership(ctx context.Context, record *Record, hetznerRecord *gohetznerdns.Record) diag.Diagnostics {
+	diagnostics := diag.Diagnostics{}
+	hasOwnershipRecord := !record.OwnerRecordId.IsNull() && !record.OwnerRecordId.IsUnknown()
+
+	if record.Owner.IsNull() || record.Owner.ValueString() == "" {
+		if hasOwnershipRecord {
+			if err := s.client.DeleteRecord(ctx, record.OwnerRecordId.ValueStringPointer()); err != nil {
+				api.AddError(&diagnostics, err)
+				return diagnostics
+			}
+		}
+		record.OwnerRecordId = types.StringNull()
+		return diagnostics
+	}
+
+	txtType := "TXT"
+	name := ownershipRecordName(*hetznerRecord.Type, *hetznerRecord.Name)
+	value := ownershipRecordValue(record.Owner.ValueString(), *hetznerRecord.Id)
+	ownershipRecord := &gohetznerdns.Record{
+		Type:   &txtType,
+		ZoneId: hetznerRecord.ZoneId,
+		Name:   &name,
+		Value:  &value,
+		TTL:    hetznerRecord.TTL,
+	}
+
+	var err error
+	if hasOwnershipRecord {
+		ownershipRecord.Id = record.OwnerRecordId.ValueStringPointer()
+		_, err = s.client.UpdateRecord(ctx, ownershipRecord)
+	} else {
+		ownershipRecord, err = s.client.CreateRecord(ctx, ownershipRecord)
+	}
+	if err != nil {
+		api.AddError(&diagnostics, err)
+		return diagnostics
+	}
+	record.OwnerRecordId = types.StringValue(*ownershipRecord.Id)
+	return diagnostics
+}
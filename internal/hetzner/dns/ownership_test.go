@@ -0,0 +1,34 @@
+package dns
+
+import (
+	"testing"
+
+	"github.com/opsheaven/gohetznerdns"
+)
+
+func TestOwnershipRecordName(t *testing.T) {
+	tests := map[string][2]string{
+		"_tf-owner-a":          {"A", "@"},
+		"_tf-owner-cname.www":  {"CNAME", "www"},
+		"_tf-owner-aaaa.files": {"AAAA", "*.files"},
+	}
+	for expected, record := range tests {
+		if name := ownershipRecordName(record[0], record[1]); name != expected {
+			t.Errorf("ownershipRecordName(%q, %q) = %q, expected %q", record[0], record[1], name, expected)
+		}
+	}
+}
+
+func TestParseOwnershipRecord(t *testing.T) {
+	txt := "TXT"
+	value := ownershipRecordValue("team-a", "record-1")
+	o, ok := parseOwnershipRecord(&gohetznerdns.Record{Type: &txt, Value: &value})
+	if !ok || o.owner != "team-a" || o.recordId != "record-1" {
+		t.Errorf("ownership record %s can not be parsed: %v", value, o)
+	}
+
+	other := "\"v=spf1 -all\""
+	if _, ok := parseOwnershipRecord(&gohetznerdns.Record{Type: &txt, Value: &other}); ok {
+		t.Errorf("%s should not be parsed as ownership record", other)
+	}
+}
@@ -1,51 +1,58 @@
 package dns
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/opsheaven/gohetznerdns"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/api"
 )
 
 type RecordService interface {
-	List(records *Records) diag.Diagnostics
-	Read(record *Record) diag.Diagnostics
-	Create(record *Record) diag.Diagnostics
-	Update(record *Record) diag.Diagnostics
-	Delete(record *Record) diag.Diagnostics
+	List(ctx context.Context, records *Records) diag.Diagnostics
+	Read(ctx context.Context, record *Record) diag.Diagnostics
+	Create(ctx context.Context, record *RecordResource) diag.Diagnostics
+	Update(ctx context.Context, record *RecordResource) diag.Diagnostics
+	Delete(ctx context.Context, record *RecordResource) diag.Diagnostics
 }
 
 type recordServiceImpl struct {
-	client gohetznerdns.RecordService
+	client      *recordClient
+	reverse     *reverseRecordServiceImpl
+	locks       *zoneLocks
+	consistency *consistencyWaiter
 }
 
 var _ RecordService = &recordServiceImpl{}
 
-func newRecordService(service gohetznerdns.RecordService) RecordService {
-	return &recordServiceImpl{client: service}
+func newRecordService(service *recordClient, reverse *reverseRecordServiceImpl, locks *zoneLocks, consistency *consistencyWaiter) RecordService {
+	return &recordServiceImpl{client: service, reverse: reverse, locks: locks, consistency: consistency}
 }
 
-func (s *recordServiceImpl) List(records *Records) diag.Diagnostics {
+func (s *recordServiceImpl) List(ctx context.Context, records *Records) diag.Diagnostics {
 	diagnostics := diag.Diagnostics{}
-	hetznerRecords, err := s.client.GetAllRecords(records.ZoneId.ValueStringPointer())
+	hetznerRecords, err := s.client.GetAllRecords(ctx, records.ZoneId.ValueStringPointer())
 
 	if err != nil {
-		diagnostics.AddError("Hetzer Client Error", err.Error())
+		api.AddError(&diagnostics, err)
 	} else {
 		diagnostics.Append(records.mapFromHetznerRecords(hetznerRecords)...)
+		records.applyOwnership(hetznerRecords)
 	}
 
 	return diagnostics
 }
 
-func (s *recordServiceImpl) Read(record *Record) diag.Diagnostics {
+func (s *recordServiceImpl) Read(ctx context.Context, record *Record) diag.Diagnostics {
 	diagnostics := diag.Diagnostics{}
 	if !record.Id.IsNull() && record.Id.String() != "" {
-		hetznerRecord, err := s.client.GetRecord(record.Id.ValueStringPointer())
+		hetznerRecord, err := s.client.GetRecord(ctx, record.Id.ValueStringPointer())
 		if err != nil {
-			diagnostics.AddError("Hetzer Client Error", err.Error())
+			api.AddError(&diagnostics, err)
 		} else {
 			diagnostics.Append(record.mapFromHetznerRecord(hetznerRecord)...)
+			diagnostics.Append(s.readOwnership(ctx, record)...)
 		}
 	} else {
 		diagnostics.AddError("Configuration Error", "ID must be provided!")
@@ -53,13 +60,25 @@ func (s *recordServiceImpl) Read(record *Record) diag.Diagnostics {
 	return diagnostics
 }
 
-func (s *recordServiceImpl) Create(record *Record) diag.Diagnostics {
+func (s *recordServiceImpl) Create(ctx context.Context, record *RecordResource) diag.Diagnostics {
+	unlock, err := s.locks.lock(ctx, record.ZoneId.ValueString())
+	if err != nil {
+		diagnostics := diag.Diagnostics{}
+		api.AddError(&diagnostics, err)
+		return diagnostics
+	}
+	defer unlock()
+
 	diagnostics := diag.Diagnostics{}
 	ttl := int(record.TTL.ValueInt64())
+	name, diags := record.Name.ASCIIValue()
+	if diags.HasError() {
+		return diags
+	}
 	hetznerRecord := &gohetznerdns.Record{
 		Type:   record.Type.ValueStringPointer(),
 		ZoneId: record.ZoneId.ValueStringPointer(),
-		Name:   record.Name.ValueStringPointer(),
+		Name:   &name,
 		TTL:    &ttl,
 	}
 	var value string
@@ -70,23 +89,40 @@ func (s *recordServiceImpl) Create(record *Record) diag.Diagnostics {
 	}
 	hetznerRecord.Value = &value
 
-	hetznerRecord, err := s.client.CreateRecord(hetznerRecord)
+	hetznerRecord, err = s.client.CreateRecord(ctx, hetznerRecord)
 	if err != nil {
-		diagnostics.AddError("Hetzer Client Error", err.Error())
+		api.AddError(&diagnostics, err)
 	} else {
 		diagnostics.Append(record.mapFromHetznerRecord(hetznerRecord)...)
+		if err := s.consistency.waitForRecord(ctx, s.client, hetznerRecord); err != nil {
+			api.AddError(&diagnostics, err)
+		}
+		diagnostics.Append(s.reverse.syncPTR(ctx, record, hetznerRecord)...)
+		diagnostics.Append(s.syncOwnership(ctx, &record.Record, hetznerRecord)...)
 	}
 	return diagnostics
 }
 
-func (s *recordServiceImpl) Update(record *Record) diag.Diagnostics {
+func (s *recordServiceImpl) Update(ctx context.Context, record *RecordResource) diag.Diagnostics {
+	unlock, err := s.locks.lock(ctx, record.ZoneId.ValueString())
+	if err != nil {
+		diagnostics := diag.Diagnostics{}
+		api.AddError(&diagnostics, err)
+		return diagnostics
+	}
+	defer unlock()
+
 	ttl := int(record.TTL.ValueInt64())
 	diagnostics := diag.Diagnostics{}
+	name, diags := record.Name.ASCIIValue()
+	if diags.HasError() {
+		return diags
+	}
 	hetznerRecord := &gohetznerdns.Record{
 		Id:     record.Id.ValueStringPointer(),
 		Type:   record.Type.ValueStringPointer(),
 		ZoneId: record.ZoneId.ValueStringPointer(),
-		Name:   record.Name.ValueStringPointer(),
+		Name:   &name,
 		TTL:    &ttl,
 	}
 	var value string
@@ -96,24 +132,48 @@ func (s *recordServiceImpl) Update(record *Record) diag.Diagnostics {
 		value = record.Value.ValueString()
 	}
 	hetznerRecord.Value = &value
-	hetznerRecord, err := s.client.UpdateRecord(hetznerRecord)
+	hetznerRecord, err = s.client.UpdateRecord(ctx, hetznerRecord)
 	if err != nil {
-		diagnostics.AddError("Hetzer Client Error", err.Error())
+		api.AddError(&diagnostics, err)
 	} else {
 		if hetznerRecord.TTL == nil {
 
 			hetznerRecord.TTL = &ttl
 		}
 		diagnostics.Append(record.mapFromHetznerRecord(hetznerRecord)...)
+		if err := s.consistency.waitForRecord(ctx, s.client, hetznerRecord); err != nil {
+			api.AddError(&diagnostics, err)
+		}
+		diagnostics.Append(s.reverse.syncPTR(ctx, record, hetznerRecord)...)
+		diagnostics.Append(s.syncOwnership(ctx, &record.Record, hetznerRecord)...)
 	}
 	return diagnostics
 }
 
-func (s *recordServiceImpl) Delete(record *Record) diag.Diagnostics {
-	diagnostics := diag.Diagnostics{}
-	err := s.client.DeleteRecord(record.Id.ValueStringPointer())
+func (s *recordServiceImpl) Delete(ctx context.Context, record *RecordResource) diag.Diagnostics {
+	unlock, err := s.locks.lock(ctx, record.ZoneId.ValueString())
 	if err != nil {
-		diagnostics.AddError("Hetzer Client Error", err.Error())
+		diagnostics := diag.Diagnostics{}
+		api.AddError(&diagnostics, err)
+		return diagnostics
+	}
+	defer unlock()
+
+	diagnostics := diag.Diagnostics{}
+	// The PTR and ownership records are kept with the record in state.
+	if err := s.client.DeleteRecord(ctx, record.Id.ValueStringPointer()); err != nil {
+		api.AddError(&diagnostics, err)
+		return diagnostics
+	}
+	if !record.PTRRecordId.IsNull() {
+		if err := s.reverse.deletePTR(ctx, record.PTRRecordId.ValueStringPointer(), record.ZoneId.ValueString()); err != nil {
+			api.AddError(&diagnostics, err)
+		}
+	}
+	if !record.OwnerRecordId.IsNull() {
+		if err := s.client.DeleteRecord(ctx, record.OwnerRecordId.ValueStringPointer()); err != nil {
+			api.AddError(&diagnostics, err)
+		}
 	}
 	return diagnostics
 }
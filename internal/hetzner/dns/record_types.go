@@ -6,7 +6,9 @@ import (
 
 	dsSchema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	rSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -14,17 +16,34 @@ import (
 )
 
 type Record struct {
-	Id     types.String `tfsdk:"id"`
-	Type   types.String `tfsdk:"type"`
-	ZoneId types.String `tfsdk:"zone_id"`
-	Name   types.String `tfsdk:"name"`
-	Value  types.String `tfsdk:"value"`
-	TTL    types.Int64  `tfsdk:"ttl"`
+	Id            types.String `tfsdk:"id"`
+	Type          types.String `tfsdk:"type"`
+	ZoneId        types.String `tfsdk:"zone_id"`
+	Name          DomainName   `tfsdk:"name"`
+	NameUnicode   types.String `tfsdk:"name_unicode"`
+	Value         types.String `tfsdk:"value"`
+	TTL           types.Int64  `tfsdk:"ttl"`
+	Owner         types.String `tfsdk:"owner"`
+	OwnerRecordId types.String `tfsdk:"owner_record_id"`
+}
+
+// RecordResource holds the attributes only managed by the record resource.
+type RecordResource struct {
+	Record
+	CreatePTR   types.Bool   `tfsdk:"create_ptr"`
+	PTRRecordId types.String `tfsdk:"ptr_record_id"`
+}
+
+// newRecordResource returns the record without a PTR record, as created for
+// copied and imported records.
+func newRecordResource(record Record) *RecordResource {
+	return &RecordResource{Record: record, CreatePTR: types.BoolValue(false), PTRRecordId: types.StringNull()}
 }
 
 type Records struct {
-	ZoneId  types.String `tfsdk:"zone_id"`
-	Records []Record     `tfsdk:"records"`
+	ZoneId      types.String `tfsdk:"zone_id"`
+	OwnerFilter types.String `tfsdk:"owner_filter"`
+	Records     []Record     `tfsdk:"records"`
 }
 
 var RecordDataSourceSchema = dsSchema.Schema{
@@ -48,6 +67,11 @@ var RecordDataSourceSchema = dsSchema.Schema{
 			MarkdownDescription: "Record name",
 			Computed:            true,
 			Optional:            true,
+			CustomType:          DomainNameType{},
+		},
+		"name_unicode": dsSchema.StringAttribute{
+			MarkdownDescription: "Record name in unicode representation",
+			Computed:            true,
 		},
 		"value": dsSchema.StringAttribute{
 			MarkdownDescription: "Record value",
@@ -57,6 +81,14 @@ var RecordDataSourceSchema = dsSchema.Schema{
 			MarkdownDescription: "Record TTL",
 			Computed:            true,
 		},
+		"owner": dsSchema.StringAttribute{
+			MarkdownDescription: "Owner of the record, populated from the ownership TXT record.",
+			Computed:            true,
+		},
+		"owner_record_id": dsSchema.StringAttribute{
+			MarkdownDescription: "Identifier of the ownership TXT record",
+			Computed:            true,
+		},
 	},
 }
 
@@ -79,20 +111,57 @@ var RecordResourceSchema = rSchema.Schema{
 			Required:            true,
 		},
 		"name": rSchema.StringAttribute{
-			MarkdownDescription: "Record name",
+			MarkdownDescription: "Record name. Internationalized names can be given either in unicode or punycode.",
 			Required:            true,
+			CustomType:          DomainNameType{},
+		},
+		"name_unicode": rSchema.StringAttribute{
+			MarkdownDescription: "Record name in unicode representation",
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				unicodeNameFromName{},
+			},
 		},
 		"value": rSchema.StringAttribute{
 			MarkdownDescription: "Record value",
 			Required:            true,
 		},
 		"ttl": rSchema.Int64Attribute{
-			MarkdownDescription: "Record TTL",
-			Required:            true,
+			MarkdownDescription: "Record TTL. Defaults to `dns_ttl` of the provider `defaults` block.",
+			Optional:            true,
+			Computed:            true,
+		},
+		"create_ptr": rSchema.BoolAttribute{
+			MarkdownDescription: "Creates the PTR record of `A` and `AAAA` records in the matching reverse zone, when the reverse zone is managed in the same account. Defaults to `false`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+		"ptr_record_id": rSchema.StringAttribute{
+			MarkdownDescription: "Identifier of the PTR record created by `create_ptr`",
+			Computed:            true,
+		},
+		"owner": rSchema.StringAttribute{
+			MarkdownDescription: "Owner of the record, e.g. the Terraform workspace managing it. Hetzner records have no labels, so the owner is persisted as a sibling TXT record named `_tf-owner-<type>.<name>`.",
+			Optional:            true,
+		},
+		"owner_record_id": rSchema.StringAttribute{
+			MarkdownDescription: "Identifier of the ownership TXT record created for `owner`",
+			Computed:            true,
 		},
 	},
 }
 
+// RecordResourceFieldPaths maps the fields of Hetzner API validation errors to
+// the attributes of RecordResourceSchema.
+var RecordResourceFieldPaths = map[string]path.Path{
+	"type":    path.Root("type"),
+	"zone_id": path.Root("zone_id"),
+	"name":    path.Root("name"),
+	"value":   path.Root("value"),
+	"ttl":     path.Root("ttl"),
+}
+
 var RecordsDataSourceSchema = dsSchema.Schema{
 	MarkdownDescription: "Hetzner Zone Records Data Source",
 	Attributes: map[string]dsSchema.Attribute{
@@ -100,6 +169,10 @@ var RecordsDataSourceSchema = dsSchema.Schema{
 			MarkdownDescription: "Hetzner Zone Identifier",
 			Required:            true,
 		},
+		"owner_filter": dsSchema.StringAttribute{
+			MarkdownDescription: "Only returns records owned by the given owner. See `owner` of the record resource. The provider has no authoritative zone resource that manages all records of a zone, so this data source is the only place that filters by owner.",
+			Optional:            true,
+		},
 		"records": dsSchema.ListNestedAttribute{
 			MarkdownDescription: "List of records created in the zone.",
 			Computed:            true,
@@ -113,7 +186,8 @@ var RecordsDataSourceSchema = dsSchema.Schema{
 func (r *Record) mapFromHetznerRecord(record *gohetznerdns.Record) diag.Diagnostics {
 	var diagnostics diag.Diagnostics
 	r.Id = types.StringValue(*record.Id)
-	r.Name = types.StringValue(*record.Name)
+	r.Name = NewDomainNameValue(*record.Name)
+	r.NameUnicode = r.Name.UnicodeValue()
 	if record.TTL != nil {
 		r.TTL = types.Int64Value(int64(*record.TTL))
 	}
@@ -138,4 +212,4 @@ func (r *Records) mapFromHetznerRecords(hetznerRecords []*gohetznerdns.Record) d
 	return diagnostics
 }
 
-var allowedRecordTypes = []string{"A", "AAAA", "NS", "MX", "CNAME", "RP", "TXT", "SOA", "HINFO", "SRV", "DANE", "TLSA", "DS", "CAA"}
+var allowedRecordTypes = []string{"A", "AAAA", "NS", "MX", "CNAME", "RP", "TXT", "SOA", "PTR", "HINFO", "SRV", "DANE", "TLSA", "DS", "CAA"}
@@ -0,0 +1,238 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/opsheaven/gohetznerdns"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/api"
+)
+
+type ReverseRecordService interface {
+	Create(ctx context.Context, records *ReverseRecords) diag.Diagnostics
+	Read(ctx context.Context, records *ReverseRecords) diag.Diagnostics
+	Delete(ctx context.Context, records *ReverseRecords) diag.Diagnostics
+}
+
+type reverseRecordServiceImpl struct {
+	records *recordClient
+	zones   *zoneClient
+	locks   *zoneLocks
+}
+
+var _ ReverseRecordService = &reverseRecordServiceImpl{}
+
+func newReverseRecordService(records *recordClient, zones *zoneClient, locks *zoneLocks) *reverseRecordServiceImpl {
+	return &reverseRecordServiceImpl{records: records, zones: zones, locks: locks}
+}
+
+func (s *reverseRecordServiceImpl) Create(ctx context.Context, records *ReverseRecords) diag.Diagnostics {
+	diagnostics := diag.Diagnostics{}
+	zone, err := s.zones.GetZoneById(ctx, records.ZoneId.ValueStringPointer())
+	if err != nil {
+		api.AddError(&diagnostics, err)
+		return diagnostics
+	}
+	hetznerRecords, err := s.records.GetAllRecords(ctx, zone.Id)
+	if err != nil {
+		api.AddError(&diagnostics, err)
+		return diagnostics
+	}
+	reverseZones, err := s.reverseZones(ctx)
+	if err != nil {
+		api.AddError(&diagnostics, err)
+		return diagnostics
+	}
+
+	records.Id = records.ZoneId
+	records.Records = []ReverseRecord{}
+	for _, hetznerRecord := range hetznerRecords {
+		if !isAddressRecord(*hetznerRecord.Type) {
+			continue
+		}
+		ptr, diags := s.createPTR(ctx, hetznerRecord, *zone.Name, reverseZones)
+		diagnostics.Append(diags...)
+		if ptr == nil {
+			continue
+		}
+		reverseRecord := ReverseRecord{}
+		reverseRecord.mapFromHetznerRecord(ptr, *hetznerRecord.Id)
+		records.Records = append(records.Records, reverseRecord)
+	}
+	return diagnostics
+}
+
+func (s *reverseRecordServiceImpl) Read(ctx context.Context, records *ReverseRecords) diag.Diagnostics {
+	diagnostics := diag.Diagnostics{}
+	existing := []ReverseRecord{}
+	for _, record := range records.Records {
+		hetznerRecord, err := s.records.GetRecord(ctx, record.Id.ValueStringPointer())
+		if api.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			// The state is kept, so later applies do not duplicate the records.
+			api.AddError(&diagnostics, err)
+			return diagnostics
+		}
+		record.mapFromHetznerRecord(hetznerRecord, record.RecordId.ValueString())
+		existing = append(existing, record)
+	}
+	records.Records = existing
+	return diagnostics
+}
+
+func (s *reverseRecordServiceImpl) Delete(ctx context.Context, records *ReverseRecords) diag.Diagnostics {
+	diagnostics := diag.Diagnostics{}
+	for _, record := range records.Records {
+		if err := s.deleteRecord(ctx, record.Id.ValueStringPointer(), record.ZoneId.ValueString(), ""); err != nil {
+			api.AddError(&diagnostics, err)
+		}
+	}
+	return diagnostics
+}
+
+func (s *reverseRecordServiceImpl) reverseZones(ctx context.Context) ([]*gohetznerdns.Zone, error) {
+	suffix := "arpa"
+	return s.zones.GetAllZonesByName(ctx, &suffix)
+}
+
+// createPTR writes the PTR record of an A or AAAA record. A warning is
+// returned when the account does not manage a matching reverse zone.
+func (s *reverseRecordServiceImpl) createPTR(ctx context.Context, record *gohetznerdns.Record, zoneName string, reverseZones []*gohetznerdns.Zone) (*gohetznerdns.Record, diag.Diagnostics) {
+	ptr, diagnostics := newPTR(record, zoneName, reverseZones)
+	if ptr == nil {
+		return nil, diagnostics
+	}
+	ptr, err := s.createRecord(ctx, ptr, "")
+	if err != nil {
+		api.AddError(&diagnostics, err)
+		return nil, diagnostics
+	}
+	return ptr, diagnostics
+}
+
+// lockReverseZone acquires the lock of the reverse zone, unless it is the
+// zone whose lock is already held by the caller.
+func (s *reverseRecordServiceImpl) lockReverseZone(ctx context.Context, zoneId, heldZoneId string) (func(), error) {
+	if zoneId == heldZoneId {
+		return func() {}, nil
+	}
+	return s.locks.lock(ctx, zoneId)
+}
+
+func (s *reverseRecordServiceImpl) createRecord(ctx context.Context, ptr *gohetznerdns.Record, heldZoneId string) (*gohetznerdns.Record, error) {
+	unlock, err := s.lockReverseZone(ctx, *ptr.ZoneId, heldZoneId)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	return s.records.CreateRecord(ctx, ptr)
+}
+
+func (s *reverseRecordServiceImpl) deleteRecord(ctx context.Context, id *string, zoneId, heldZoneId string) error {
+	unlock, err := s.lockReverseZone(ctx, zoneId, heldZoneId)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return s.records.DeleteRecord(ctx, id)
+}
+
+// deletePTR deletes the PTR record tracked by a record of the zone whose lock
+// is held by the caller. PTR records which are already gone are ignored.
+func (s *reverseRecordServiceImpl) deletePTR(ctx context.Context, id *string, heldZoneId string) error {
+	ptr, err := s.records.GetRecord(ctx, id)
+	if api.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return s.deleteRecord(ctx, ptr.Id, *ptr.ZoneId, heldZoneId)
+}
+
+// newPTR returns the PTR record of an A or AAAA record in the matching
+// reverse zone, or nil with a warning when there is none.
+func newPTR(record *gohetznerdns.Record, zoneName string, reverseZones []*gohetznerdns.Zone) (*gohetznerdns.Record, diag.Diagnostics) {
+	diagnostics := diag.Diagnostics{}
+	value := strings.Trim(*record.Value, "\"")
+	reverseName, err := ReverseName(value)
+	if err != nil {
+		diagnostics.AddWarning("Invalid Address", fmt.Sprintf("PTR record for %s can not be created: %s", value, err.Error()))
+		return nil, diagnostics
+	}
+	reverseZone := findReverseZone(reverseName, reverseZones)
+	if reverseZone == nil {
+		diagnostics.AddWarning("Missing Reverse Zone", fmt.Sprintf("PTR record %s is not created as there is no reverse zone managed in the account.", reverseName))
+		return nil, diagnostics
+	}
+
+	ptrType := "PTR"
+	ptrName := strings.TrimSuffix(reverseName, "."+*reverseZone.Name)
+	ptrValue := fqdn(*record.Name, zoneName)
+	return &gohetznerdns.Record{
+		Type:   &ptrType,
+		ZoneId: reverseZone.Id,
+		Name:   &ptrName,
+		Value:  &ptrValue,
+		TTL:    record.TTL,
+	}, diagnostics
+}
+
+// syncPTR keeps the PTR record tracked by the record in line with
+// `create_ptr` and the address. The PTR record is only replaced when its
+// reverse name or target changes.
+func (s *reverseRecordServiceImpl) syncPTR(ctx context.Context, record *RecordResource, hetznerRecord *gohetznerdns.Record) diag.Diagnostics {
+	diagnostics := diag.Diagnostics{}
+	var desired *gohetznerdns.Record
+	if record.CreatePTR.ValueBool() && isAddressRecord(*hetznerRecord.Type) {
+		zone, err := s.zones.GetZoneById(ctx, hetznerRecord.ZoneId)
+		if err != nil {
+			api.AddError(&diagnostics, err)
+			return diagnostics
+		}
+		reverseZones, err := s.reverseZones(ctx)
+		if err != nil {
+			api.AddError(&diagnostics, err)
+			return diagnostics
+		}
+		var diags diag.Diagnostics
+		desired, diags = newPTR(hetznerRecord, *zone.Name, reverseZones)
+		diagnostics.Append(diags...)
+	}
+
+	if !record.PTRRecordId.IsNull() && !record.PTRRecordId.IsUnknown() {
+		existing, err := s.records.GetRecord(ctx, record.PTRRecordId.ValueStringPointer())
+		if err != nil && !api.IsNotFound(err) {
+			api.AddError(&diagnostics, err)
+			return diagnostics
+		}
+		if existing != nil && desired != nil && samePTR(existing, desired) {
+			return diagnostics
+		}
+		if existing != nil {
+			if err := s.deleteRecord(ctx, existing.Id, *existing.ZoneId, *hetznerRecord.ZoneId); err != nil {
+				api.AddError(&diagnostics, err)
+				return diagnostics
+			}
+		}
+	}
+	record.PTRRecordId = types.StringNull()
+	if desired == nil {
+		return diagnostics
+	}
+
+	ptr, err := s.createRecord(ctx, desired, *hetznerRecord.ZoneId)
+	if err != nil {
+		api.AddError(&diagnostics, err)
+		return diagnostics
+	}
+	record.PTRRecordId = types.StringValue(*ptr.Id)
+	return diagnostics
+}
+
+func samePTR(existing, desired *gohetznerdns.Record) bool {
+	return equalString(existing.ZoneId, desired.ZoneId) && equalString(existing.Name, desired.Name) && equalString(existing.Value, desired.Value)
+}
@@ -0,0 +1,74 @@
+package dns
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/dns/dnstest"
+)
+
+// newTestReverseZone creates example.com with an A record and its reverse
+// zone.
+func newTestReverseZone(server *dnstest.Server) (dnstest.Zone, dnstest.Zone) {
+	zone := server.AddZone("example.com", 3600)
+	server.AddRecord(zone.Id, "A", "www", "192.0.2.1", nil)
+	reverse := server.AddZone("2.0.192.in-addr.arpa", 3600)
+	return zone, reverse
+}
+
+func TestReverseRecordServiceReadKeepsRecordsOnErrors(t *testing.T) {
+	services, server := newTestServices(t)
+	ctx := context.Background()
+	zone, _ := newTestReverseZone(server)
+
+	records := &ReverseRecords{ZoneId: types.StringValue(zone.Id)}
+	if diags := services.ReverseRecordService().Create(ctx, records); diags.HasError() || len(records.Records) != 1 {
+		t.Fatalf("create failed with %d records: %v", len(records.Records), diags)
+	}
+
+	server.InjectFault(dnstest.Fault{Method: http.MethodGet, Path: "/records/", StatusCode: http.StatusInternalServerError})
+	if diags := services.ReverseRecordService().Read(ctx, records); !diags.HasError() || len(records.Records) != 1 {
+		t.Errorf("failed reads should keep the records, got %d records: %v", len(records.Records), diags)
+	}
+
+	if diags := services.RecordService().Delete(ctx, newRecordResource(Record{Id: records.Records[0].Id, ZoneId: records.Records[0].ZoneId, OwnerRecordId: types.StringNull()})); diags.HasError() {
+		t.Fatalf("delete failed: %v", diags)
+	}
+	if diags := services.ReverseRecordService().Read(ctx, records); diags.HasError() || len(records.Records) != 0 {
+		t.Errorf("deleted records should be removed, got %d records: %v", len(records.Records), diags)
+	}
+}
+
+func TestRecordServiceKeepsUnchangedPTR(t *testing.T) {
+	services, server := newTestServices(t)
+	ctx := context.Background()
+	zone, reverse := newTestReverseZone(server)
+
+	record := newTestRecord(zone.Id, "A", "mail", "192.0.2.10", 300)
+	record.CreatePTR = types.BoolValue(true)
+	if diags := services.RecordService().Create(ctx, record); diags.HasError() || record.PTRRecordId.IsNull() {
+		t.Fatalf("create failed: %v", diags)
+	}
+	ptrId := record.PTRRecordId
+
+	record.TTL = types.Int64Value(600)
+	if diags := services.RecordService().Update(ctx, record); diags.HasError() || !record.PTRRecordId.Equal(ptrId) {
+		t.Errorf("TTL changes should keep PTR %s, got %s: %v", ptrId, record.PTRRecordId, diags)
+	}
+
+	record.Value = types.StringValue("192.0.2.11")
+	if diags := services.RecordService().Update(ctx, record); diags.HasError() || record.PTRRecordId.Equal(ptrId) {
+		t.Fatalf("address changes should replace the PTR: %v", diags)
+	}
+	ptrs := []string{}
+	for _, r := range server.Records(reverse.Id) {
+		if r.Type == "PTR" {
+			ptrs = append(ptrs, r.Name)
+		}
+	}
+	if len(ptrs) != 1 || ptrs[0] != "11" {
+		t.Errorf("expected the PTR of the new address only, got %v", ptrs)
+	}
+}
@@ -0,0 +1,133 @@
+package dns
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	rSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/opsheaven/gohetznerdns"
+)
+
+type ReverseRecord struct {
+	Id       types.String `tfsdk:"id"`
+	ZoneId   types.String `tfsdk:"zone_id"`
+	Name     types.String `tfsdk:"name"`
+	Value    types.String `tfsdk:"value"`
+	RecordId types.String `tfsdk:"record_id"`
+}
+
+type ReverseRecords struct {
+	Id      types.String    `tfsdk:"id"`
+	ZoneId  types.String    `tfsdk:"zone_id"`
+	Records []ReverseRecord `tfsdk:"records"`
+}
+
+var ReverseRecordsResourceSchema = rSchema.Schema{
+	MarkdownDescription: "Hetzner Reverse Zone Records Resource. Creates PTR records for all A and AAAA records of the zone in the matching reverse zones managed in the same account. " +
+		"Records are generated at creation time, use `replace_triggered_by` to regenerate them when the zone records change.",
+	Attributes: map[string]rSchema.Attribute{
+		"id": rSchema.StringAttribute{
+			MarkdownDescription: "Resource Identifier. Same as the `zone_id`.",
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"zone_id": rSchema.StringAttribute{
+			MarkdownDescription: "Zone identifier whose A and AAAA records are published as PTR records",
+			Required:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"records": rSchema.ListNestedAttribute{
+			MarkdownDescription: "PTR records created in the reverse zones.",
+			Computed:            true,
+			PlanModifiers: []planmodifier.List{
+				listplanmodifier.UseStateForUnknown(),
+			},
+			NestedObject: rSchema.NestedAttributeObject{
+				Attributes: map[string]rSchema.Attribute{
+					"id": rSchema.StringAttribute{
+						MarkdownDescription: "PTR Record Identifier",
+						Computed:            true,
+					},
+					"zone_id": rSchema.StringAttribute{
+						MarkdownDescription: "Reverse zone identifier that PTR record belongs to",
+						Computed:            true,
+					},
+					"name": rSchema.StringAttribute{
+						MarkdownDescription: "PTR record name relative to the reverse zone",
+						Computed:            true,
+					},
+					"value": rSchema.StringAttribute{
+						MarkdownDescription: "Fully qualified name the address resolves to",
+						Computed:            true,
+					},
+					"record_id": rSchema.StringAttribute{
+						MarkdownDescription: "Identifier of the A or AAAA record the PTR record is generated from",
+						Computed:            true,
+					},
+				},
+			},
+		},
+	},
+}
+
+// ReverseName returns the in-addr.arpa or ip6.arpa name of the given address.
+func ReverseName(address string) (string, error) {
+	ip, err := netip.ParseAddr(address)
+	if err != nil {
+		return "", err
+	}
+	ip = ip.Unmap()
+	if ip.Is4() {
+		b := ip.As4()
+		return fmt.Sprintf("%d.%d.%d.%d.in-addr.arpa", b[3], b[2], b[1], b[0]), nil
+	}
+	b := ip.As16()
+	nibbles := make([]string, 0, 32)
+	for i := len(b) - 1; i >= 0; i-- {
+		nibbles = append(nibbles, fmt.Sprintf("%x", b[i]&0x0f), fmt.Sprintf("%x", b[i]>>4))
+	}
+	return strings.Join(nibbles, ".") + ".ip6.arpa", nil
+}
+
+// fqdn returns the fully qualified name of a record in the given zone.
+func fqdn(recordName, zoneName string) string {
+	if recordName == "@" || recordName == "" {
+		return zoneName + "."
+	}
+	return recordName + "." + zoneName + "."
+}
+
+// findReverseZone returns the most specific zone containing the reverse name.
+func findReverseZone(reverseName string, zones []*gohetznerdns.Zone) *gohetznerdns.Zone {
+	var found *gohetznerdns.Zone
+	for _, zone := range zones {
+		if zone.Name == nil || !strings.HasSuffix(reverseName, "."+*zone.Name) {
+			continue
+		}
+		if found == nil || len(*zone.Name) > len(*found.Name) {
+			found = zone
+		}
+	}
+	return found
+}
+
+func isAddressRecord(recordType string) bool {
+	return recordType == "A" || recordType == "AAAA"
+}
+
+func (r *ReverseRecord) mapFromHetznerRecord(record *gohetznerdns.Record, recordId string) {
+	r.Id = types.StringValue(*record.Id)
+	r.ZoneId = types.StringValue(*record.ZoneId)
+	r.Name = types.StringValue(*record.Name)
+	r.Value = types.StringValue(*record.Value)
+	r.RecordId = types.StringValue(recordId)
+}
@@ -0,0 +1,66 @@
+package dns
+
+import (
+	"testing"
+
+	"github.com/opsheaven/gohetznerdns"
+)
+
+func TestReverseName(t *testing.T) {
+	tests := []struct {
+		address  string
+		expected string
+	}{
+		{"192.0.2.10", "10.2.0.192.in-addr.arpa"},
+		{"::ffff:192.0.2.10", "10.2.0.192.in-addr.arpa"},
+		{"2001:db8::567:89ab", "b.a.9.8.7.6.5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa"},
+		{"::1", "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.ip6.arpa"},
+	}
+	for _, test := range tests {
+		name, err := ReverseName(test.address)
+		if err != nil {
+			t.Errorf("ReverseName(%q) returned error: %s", test.address, err)
+		}
+		if name != test.expected {
+			t.Errorf("ReverseName(%q) = %q, expected %q", test.address, name, test.expected)
+		}
+	}
+}
+
+func TestReverseNameInvalidAddress(t *testing.T) {
+	for _, address := range []string{"", "example.com", "192.0.2.300", "2001:db8::g"} {
+		if _, err := ReverseName(address); err == nil {
+			t.Errorf("ReverseName(%q) should return error", address)
+		}
+	}
+}
+
+func TestFindReverseZone(t *testing.T) {
+	zone := func(id, name string) *gohetznerdns.Zone {
+		return &gohetznerdns.Zone{Id: &id, Name: &name}
+	}
+	zones := []*gohetznerdns.Zone{
+		zone("1", "192.in-addr.arpa"),
+		zone("2", "2.0.192.in-addr.arpa"),
+		zone("3", "8.b.d.0.1.0.0.2.ip6.arpa"),
+	}
+
+	if found := findReverseZone("10.2.0.192.in-addr.arpa", zones); found == nil || *found.Id != "2" {
+		t.Errorf("most specific reverse zone should be selected, got %v", found)
+	}
+	if found := findReverseZone("10.3.0.192.in-addr.arpa", zones); found == nil || *found.Id != "1" {
+		t.Errorf("parent reverse zone should be selected, got %v", found)
+	}
+	if found := findReverseZone("10.2.0.193.in-addr.arpa", zones); found != nil {
+		t.Errorf("no reverse zone should be selected, got %s", *found.Name)
+	}
+}
+
+func TestFqdn(t *testing.T) {
+	if name := fqdn("@", "example.com"); name != "example.com." {
+		t.Errorf("apex record name is %q", name)
+	}
+	if name := fqdn("www", "example.com"); name != "www.example.com." {
+		t.Errorf("record name is %q", name)
+	}
+}
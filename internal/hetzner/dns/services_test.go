@@ -0,0 +1,284 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/api"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/dns/dnstest"
+)
+
+func newTestServices(t *testing.T) (DNSServices, *dnstest.Server) {
+	t.Helper()
+	server := dnstest.NewServer(t)
+	services, diags := NewClient(Config{Token: dnstest.Token, Endpoint: server.URL}, http.DefaultClient)
+	if diags.HasError() {
+		t.Fatalf("client initialization failed: %v", diags)
+	}
+	return services, server
+}
+
+func newTestRecord(zoneId, recordType, name, value string, ttl int64) *RecordResource {
+	return newRecordResource(Record{
+		Type:          types.StringValue(recordType),
+		ZoneId:        types.StringValue(zoneId),
+		Name:          NewDomainNameValue(name),
+		Value:         types.StringValue(value),
+		TTL:           types.Int64Value(ttl),
+		Owner:         types.StringNull(),
+		OwnerRecordId: types.StringNull(),
+	})
+}
+
+func TestZoneServiceLifecycle(t *testing.T) {
+	services, server := newTestServices(t)
+	ctx := context.Background()
+
+	zone := &ZoneResource{Zone: Zone{Id: types.StringNull(), Name: NewDomainNameValue("bücher.example"), TTL: types.Int64Value(3600)}, CopyRecordsFromZoneId: types.StringNull()}
+	if diags := services.ZoneService().Create(ctx, zone); diags.HasError() {
+		t.Fatalf("create failed: %v", diags)
+	}
+	if zone.Name.ValueString() != "xn--bcher-kva.example" || zone.NameUnicode.ValueString() != "bücher.example" || len(zone.NS.Elements()) != 3 {
+		t.Errorf("unexpected zone %+v", zone)
+	}
+
+	zone.TTL = types.Int64Value(7200)
+	if diags := services.ZoneService().Update(ctx, &zone.Zone); diags.HasError() {
+		t.Fatalf("update failed: %v", diags)
+	}
+	read := &Zone{Id: types.StringNull(), Name: NewDomainNameValue("bücher.example")}
+	if diags := services.ZoneService().Read(ctx, read); diags.HasError() || read.Id != zone.Id || read.TTL.ValueInt64() != 7200 {
+		t.Errorf("read by name returned %+v: %v", read, diags)
+	}
+
+	if diags := services.ZoneService().Delete(ctx, &zone.Zone); diags.HasError() {
+		t.Fatalf("delete failed: %v", diags)
+	}
+	if len(server.Zones()) != 0 {
+		t.Errorf("zone should be deleted, got %v", server.Zones())
+	}
+	diags := services.ZoneService().Read(ctx, &Zone{Id: zone.Id})
+	if !diags.HasError() || diags[0].Summary() != "Hetzner Resource Not Found" {
+		t.Errorf("reading deleted zone should fail with not found, got %v", diags)
+	}
+}
+
+func TestZoneServiceRejectsDuplicateZones(t *testing.T) {
+	services, server := newTestServices(t)
+	server.AddZone("example.com", 3600)
+
+	diags := services.ZoneService().Create(context.Background(), &ZoneResource{Zone: Zone{Id: types.StringNull(), Name: NewDomainNameValue("example.com"), TTL: types.Int64Value(3600)}, CopyRecordsFromZoneId: types.StringNull()})
+	if !diags.HasError() || diags[0].Summary() != "Hetzner Resource Conflict" {
+		t.Errorf("expected conflict, got %v", diags)
+	}
+}
+
+func TestZoneServiceCopiesRecordsInBulk(t *testing.T) {
+	services, server := newTestServices(t)
+	ctx := context.Background()
+	source := server.AddZone("example.com", 3600)
+	server.AddRecord(source.Id, "A", "www", "192.0.2.1", nil)
+	server.AddRecord(source.Id, "CNAME", "docs", "www.example.com.", nil)
+	server.AddRecord(source.Id, "TXT", "_tf-owner-a.www", "heritage=terraform", nil)
+
+	zone := &ZoneResource{
+		Zone:                  Zone{Id: types.StringNull(), Name: NewDomainNameValue("example.org"), TTL: types.Int64Value(600)},
+		CopyRecordsFromZoneId: types.StringValue(source.Id),
+		CopyRewriteRules:      []RewriteRule{{Pattern: types.StringValue(`example\.com\.$`), Replacement: types.StringValue("example.org.")}},
+	}
+	requests := server.Requests()
+	if diags := services.ZoneService().Create(ctx, zone); diags.HasError() {
+		t.Fatalf("create failed: %v", diags)
+	}
+	if requests := server.Requests() - requests; requests != 3 {
+		t.Errorf("records should be copied with a single request, sent %d requests", requests)
+	}
+	copied := map[string]dnstest.Record{}
+	for _, record := range server.Records(zone.Id.ValueString()) {
+		copied[record.Type+" "+record.Name] = record
+	}
+	if www := copied["A www"]; www.Value != "192.0.2.1" || www.TTL == nil || *www.TTL != 600 {
+		t.Errorf("A record should be copied with the zone TTL, got %+v", www)
+	}
+	if docs := copied["CNAME docs"]; docs.Value != "www.example.org." {
+		t.Errorf("CNAME record should be rewritten, got %+v", docs)
+	}
+	if _, ok := copied["TXT _tf-owner-a.www"]; ok {
+		t.Error("ownership records should not be copied")
+	}
+}
+
+func TestRecordServiceLifecycle(t *testing.T) {
+	services, server := newTestServices(t)
+	ctx := context.Background()
+	zone := server.AddZone("example.com", 3600)
+
+	record := newTestRecord(zone.Id, "A", "www", "192.0.2.1", 300)
+	if diags := services.RecordService().Create(ctx, record); diags.HasError() {
+		t.Fatalf("create failed: %v", diags)
+	}
+	record.Value = types.StringValue("192.0.2.2")
+	if diags := services.RecordService().Update(ctx, record); diags.HasError() {
+		t.Fatalf("update failed: %v", diags)
+	}
+	read := &Record{Id: record.Id}
+	if diags := services.RecordService().Read(ctx, read); diags.HasError() || read.Value.ValueString() != "192.0.2.2" || read.TTL.ValueInt64() != 300 {
+		t.Errorf("read returned %+v: %v", read, diags)
+	}
+	if diags := services.RecordService().Delete(ctx, record); diags.HasError() {
+		t.Fatalf("delete failed: %v", diags)
+	}
+	// SOA and NS records created with the zone remain.
+	if records := server.Records(zone.Id); len(records) != 4 {
+		t.Errorf("expected 4 records, got %v", records)
+	}
+}
+
+func TestRecordServiceListsAllPages(t *testing.T) {
+	services, server := newTestServices(t)
+	zone := server.AddZone("example.com", 3600)
+	for i := 0; i < 250; i++ {
+		server.AddRecord(zone.Id, "A", fmt.Sprintf("host%d", i), "192.0.2.1", nil)
+	}
+
+	records := &Records{ZoneId: types.StringValue(zone.Id), OwnerFilter: types.StringNull()}
+	if diags := services.RecordService().List(context.Background(), records); diags.HasError() {
+		t.Fatalf("list failed: %v", diags)
+	}
+	if len(records.Records) != 254 {
+		t.Errorf("expected 254 records, got %d", len(records.Records))
+	}
+}
+
+func TestRecordServiceValidationErrors(t *testing.T) {
+	services, server := newTestServices(t)
+	zone := server.AddZone("example.com", 3600)
+
+	diags := services.RecordService().Create(context.Background(), newTestRecord(zone.Id, "A", "www", "", 300))
+	if len(diags) != 1 || diags[0].Summary() != "Invalid Hetzner API Request" {
+		t.Fatalf("expected validation error, got %v", diags)
+	}
+	if _, ok := diags[0].(diag.DiagnosticWithPath); ok || diags[0].Detail() != "value: is required" {
+		t.Errorf("validation error should name the API field, got %v", diags[0])
+	}
+	diags = api.WithFieldPaths(diags, RecordResourceFieldPaths)
+	if withPath, ok := diags[0].(diag.DiagnosticWithPath); !ok || !withPath.Path().Equal(path.Root("value")) {
+		t.Errorf("validation error should point to the value attribute, got %v", diags[0])
+	}
+}
+
+func TestServicesReportRateLimits(t *testing.T) {
+	services, server := newTestServices(t)
+	server.RateLimit(1)
+
+	diags := services.ZoneService().List(context.Background(), &Zones{Name: types.StringNull()})
+	if !diags.HasError() || diags[0].Summary() != "Hetzner API Rate Limit Exceeded" {
+		t.Errorf("expected rate limit error, got %v", diags)
+	}
+}
+
+func TestServicesReportInvalidTokens(t *testing.T) {
+	server := dnstest.NewServer(t)
+	services, _ := NewClient(Config{Token: "invalid", TokenSource: "the HETZNER_DNS_API_TOKEN environment variable", Endpoint: server.URL}, http.DefaultClient)
+
+	diags := services.ZoneService().List(context.Background(), &Zones{Name: types.StringNull()})
+	if !diags.HasError() || diags[0].Summary() != "Hetzner API Authentication Failed" {
+		t.Errorf("expected authentication error, got %v", diags)
+	}
+}
+
+func TestAccountServiceReportsUsage(t *testing.T) {
+	services, server := newTestServices(t)
+	server.SetHeader("RateLimit-Limit", "300")
+	server.SetHeader("RateLimit-Remaining", "299")
+	zone := server.AddZone("example.com", 3600)
+	server.AddZone("example.org", 3600)
+	server.AddRecord(zone.Id, "A", "www", "192.0.2.1", nil)
+
+	if diags := services.AccountService().Validate(context.Background()); diags.HasError() {
+		t.Fatalf("valid token should be accepted: %v", diags)
+	}
+	account := &Account{}
+	if diags := services.AccountService().Read(context.Background(), account); diags.HasError() {
+		t.Fatalf("read failed: %v", diags)
+	}
+	// Each zone has an SOA and 3 NS records.
+	if !account.Valid.ValueBool() || account.ZoneCount.ValueInt64() != 2 || account.RecordCount.ValueInt64() != 9 ||
+		account.RateLimit.ValueInt64() != 300 || account.RateLimitRemaining.ValueInt64() != 299 {
+		t.Errorf("unexpected account %+v", account)
+	}
+}
+
+func TestAccountServiceReportsInvalidTokens(t *testing.T) {
+	server := dnstest.NewServer(t)
+	services, _ := NewClient(Config{Token: "invalid", TokenSource: "the dns_api_token provider attribute", Endpoint: server.URL}, http.DefaultClient)
+
+	if diags := services.AccountService().Validate(context.Background()); !diags.HasError() || diags[0].Summary() != "Hetzner API Authentication Failed" {
+		t.Errorf("expected authentication error, got %v", diags)
+	}
+	account := &Account{}
+	if diags := services.AccountService().Read(context.Background(), account); diags.HasError() {
+		t.Fatalf("invalid token should not fail the read: %v", diags)
+	}
+	if account.Valid.ValueBool() || !account.ZoneCount.IsNull() || account.TokenSource.ValueString() != "the dns_api_token provider attribute" {
+		t.Errorf("unexpected account %+v", account)
+	}
+}
+
+func TestRecordServiceDeleteKeepsSiblingsOnFailure(t *testing.T) {
+	services, server := newTestServices(t)
+	ctx := context.Background()
+	zone := server.AddZone("example.com", 3600)
+
+	record := newTestRecord(zone.Id, "A", "www", "192.0.2.1", 300)
+	record.Owner = types.StringValue("workspace")
+	if diags := services.RecordService().Create(ctx, record); diags.HasError() {
+		t.Fatalf("create failed: %v", diags)
+	}
+	server.InjectFault(dnstest.Fault{Method: http.MethodDelete, Path: "/records/" + record.Id.ValueString(), StatusCode: http.StatusInternalServerError})
+	if diags := services.RecordService().Delete(ctx, record); !diags.HasError() {
+		t.Fatal("expected delete to fail")
+	}
+	read := &Record{Id: record.OwnerRecordId}
+	if diags := services.RecordService().Read(ctx, read); diags.HasError() {
+		t.Errorf("ownership record should be kept when the record is not deleted: %v", diags)
+	}
+}
+
+func TestRecordServiceReadDetectsOwnershipChanges(t *testing.T) {
+	services, server := newTestServices(t)
+	ctx := context.Background()
+	zone := server.AddZone("example.com", 3600)
+
+	record := newTestRecord(zone.Id, "A", "www", "192.0.2.1", 300)
+	record.Owner = types.StringValue("workspace")
+	if diags := services.RecordService().Create(ctx, record); diags.HasError() {
+		t.Fatalf("create failed: %v", diags)
+	}
+
+	ownershipRecord := newTestRecord(zone.Id, "TXT", "_tf-owner-a.www", "heritage=terraform,terraform/owner=other,terraform/record="+record.Id.ValueString(), 300)
+	ownershipRecord.Id = record.OwnerRecordId
+	if diags := services.RecordService().Update(ctx, ownershipRecord); diags.HasError() {
+		t.Fatalf("update of the ownership record failed: %v", diags)
+	}
+	if diags := services.RecordService().Read(ctx, &record.Record); diags.HasError() || record.Owner.ValueString() != "other" {
+		t.Errorf("edited ownership records should be read back, got owner %s: %v", record.Owner, diags)
+	}
+
+	if diags := services.RecordService().Delete(ctx, ownershipRecord); diags.HasError() {
+		t.Fatalf("delete of the ownership record failed: %v", diags)
+	}
+	if diags := services.RecordService().Read(ctx, &record.Record); diags.HasError() || !record.Owner.IsNull() || !record.OwnerRecordId.IsNull() {
+		t.Errorf("deleted ownership records should be removed, got owner %s and %s: %v", record.Owner, record.OwnerRecordId, diags)
+	}
+
+	record.Owner = types.StringValue("workspace")
+	if diags := services.RecordService().Update(ctx, record); diags.HasError() || record.OwnerRecordId.IsNull() {
+		t.Errorf("update should recreate the ownership record: %v", diags)
+	}
+}
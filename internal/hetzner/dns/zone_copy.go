@@ -0,0 +1,117 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/opsheaven/gohetznerdns"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/api"
+)
+
+type RewriteRule struct {
+	Pattern     types.String `tfsdk:"pattern"`
+	Replacement types.String `tfsdk:"replacement"`
+}
+
+type rewriteRule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+func compileRewriteRules(rules []RewriteRule) ([]rewriteRule, diag.Diagnostics) {
+	diagnostics := diag.Diagnostics{}
+	compiled := []rewriteRule{}
+	for i, rule := range rules {
+		pattern, err := regexp.Compile(rule.Pattern.ValueString())
+		if err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("copy_rewrite_rules").AtListIndex(i).AtName("pattern"),
+				"Invalid Rewrite Pattern",
+				fmt.Sprintf("%s is not a valid regular expression: %s", rule.Pattern.String(), err.Error()),
+			)
+			continue
+		}
+		compiled = append(compiled, rewriteRule{pattern: pattern, replacement: rule.Replacement.ValueString()})
+	}
+	return compiled, diagnostics
+}
+
+func rewrite(value string, rules []rewriteRule) string {
+	for _, rule := range rules {
+		value = rule.pattern.ReplaceAllString(value, rule.replacement)
+	}
+	return value
+}
+
+// isCopyableRecord reports whether the record can be copied to another zone.
+// SOA and NS records are managed by Hetzner, and ownership records refer to
+// records of the source zone.
+func isCopyableRecord(record Record) bool {
+	recordType := record.Type.ValueString()
+	return recordType != "SOA" && recordType != "NS" && !strings.HasPrefix(record.Name.ValueString(), ownershipPrefix)
+}
+
+// copyRecords seeds the zone with the records of the source zone in a single
+// bulk request. Copied records are not managed by Terraform afterwards.
+func (s *zoneServiceImpl) copyRecords(ctx context.Context, zone *ZoneResource, rules []rewriteRule) diag.Diagnostics {
+	diagnostics := diag.Diagnostics{}
+	source := &Records{ZoneId: zone.CopyRecordsFromZoneId, OwnerFilter: types.StringNull()}
+	diagnostics.Append(s.records.List(ctx, source)...)
+	if diagnostics.HasError() {
+		return diagnostics
+	}
+
+	requests := []*gohetznerdns.Record{}
+	for _, sourceRecord := range source.Records {
+		if !isCopyableRecord(sourceRecord) {
+			continue
+		}
+		name, diags := NewDomainNameValue(rewrite(sourceRecord.Name.ValueString(), rules)).ASCIIValue()
+		diagnostics.Append(diags...)
+		if diags.HasError() {
+			continue
+		}
+		ttl := int(zone.TTL.ValueInt64())
+		if !sourceRecord.TTL.IsNull() {
+			ttl = int(sourceRecord.TTL.ValueInt64())
+		}
+		value := rewrite(sourceRecord.Value.ValueString(), rules)
+		requests = append(requests, &gohetznerdns.Record{
+			Type:   sourceRecord.Type.ValueStringPointer(),
+			ZoneId: zone.Id.ValueStringPointer(),
+			Name:   &name,
+			Value:  &value,
+			TTL:    &ttl,
+		})
+	}
+	if len(requests) == 0 {
+		return diagnostics
+	}
+
+	created, invalid, err := s.recordsClient.BulkCreateRecords(ctx, requests)
+	if err != nil {
+		api.AddError(&diagnostics, err)
+		return diagnostics
+	}
+	for _, record := range invalid {
+		diagnostics.AddError(
+			"Record Not Copied",
+			fmt.Sprintf("The Hetzner DNS API rejected the %s record %s with value %s.",
+				types.StringPointerValue(record.Type).ValueString(),
+				types.StringPointerValue(record.Name).ValueString(),
+				types.StringPointerValue(record.Value).ValueString(),
+			),
+		)
+	}
+	for _, record := range created {
+		if err := s.consistency.waitForRecord(ctx, s.recordsClient, record); err != nil {
+			api.AddError(&diagnostics, err)
+		}
+	}
+	return diagnostics
+}
@@ -0,0 +1,32 @@
+package dns
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRewrite(t *testing.T) {
+	rules, diags := compileRewriteRules([]RewriteRule{
+		{Pattern: types.StringValue(`example\.com\.$`), Replacement: types.StringValue("staging.example.com.")},
+		{Pattern: types.StringValue(`^(.*)-prod$`), Replacement: types.StringValue("${1}-staging")},
+	})
+	if diags.HasError() {
+		t.Fatalf("rewrite rules should compile: %v", diags)
+	}
+	if value := rewrite("www.example.com.", rules); value != "www.staging.example.com." {
+		t.Errorf("value is rewritten as %q", value)
+	}
+	if value := rewrite("api-prod", rules); value != "api-staging" {
+		t.Errorf("name is rewritten as %q", value)
+	}
+}
+
+func TestCompileRewriteRulesInvalidPattern(t *testing.T) {
+	_, diags := compileRewriteRules([]RewriteRule{
+		{Pattern: types.StringValue(`(`), Replacement: types.StringValue("")},
+	})
+	if !diags.HasError() {
+		t.Error("invalid pattern should return error")
+	}
+}
@@ -0,0 +1,82 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type ZoneImportService interface {
+	Create(ctx context.Context, zoneImport *ZoneImport) diag.Diagnostics
+}
+
+type zoneImportServiceImpl struct {
+	records RecordService
+}
+
+var _ ZoneImportService = &zoneImportServiceImpl{}
+
+func newZoneImportService(records RecordService) ZoneImportService {
+	return &zoneImportServiceImpl{records: records}
+}
+
+func (s *zoneImportServiceImpl) Create(ctx context.Context, zoneImport *ZoneImport) diag.Diagnostics {
+	diagnostics := diag.Diagnostics{}
+	key, diags := zoneImport.tsigKey()
+	if diags.HasError() {
+		return diags
+	}
+
+	records, skipped, err := transferZone(ctx, zoneImport.ZoneName.ValueString(), zoneImport.Nameserver.ValueString(), key)
+	if err != nil {
+		diagnostics.AddError("Zone Transfer Error", err.Error())
+		return diagnostics
+	}
+	if len(skipped) > 0 {
+		diagnostics.AddWarning("Unsupported Record Types", fmt.Sprintf("Records of types [ %s ] are not supported by Hetzner and skipped.", strings.Join(skipped, ",")))
+	}
+
+	zoneImport.Id = types.StringValue(fmt.Sprintf("%s@%s", zoneImport.ZoneName.ValueString(), zoneImport.Nameserver.ValueString()))
+	zoneImport.Records = []ImportedRecord{}
+	for _, record := range records.Records {
+		if !zoneImport.ZoneId.IsNull() && isUploadableRecord(record) {
+			record.ZoneId = zoneImport.ZoneId
+			created := newRecordResource(record)
+			diagnostics.Append(s.records.Create(ctx, created)...)
+			record = created.Record
+		}
+		importedRecord := ImportedRecord{}
+		importedRecord.mapFromRecord(record)
+		zoneImport.Records = append(zoneImport.Records, importedRecord)
+	}
+	return diagnostics
+}
+
+// isUploadableRecord reports whether the transferred record can be created in
+// a Hetzner zone. SOA and apex NS records are managed by Hetzner.
+func isUploadableRecord(record Record) bool {
+	recordType := record.Type.ValueString()
+	return recordType != "SOA" && !(recordType == "NS" && record.Name.ValueString() == "@")
+}
+
+func (z *ZoneImport) tsigKey() (*TSIGKey, diag.Diagnostics) {
+	diagnostics := diag.Diagnostics{}
+	if z.TSIGKeyName.IsNull() {
+		return nil, diagnostics
+	}
+	if z.TSIGAlgorithm.IsNull() {
+		diagnostics.AddAttributeError(path.Root("tsig_algorithm"), "Missing TSIG Algorithm", "tsig_algorithm must be provided with tsig_key_name")
+	}
+	if z.TSIGSecret.IsNull() {
+		diagnostics.AddAttributeError(path.Root("tsig_secret"), "Missing TSIG Secret", "tsig_secret must be provided with tsig_key_name")
+	}
+	return &TSIGKey{
+		Name:      z.TSIGKeyName.ValueString(),
+		Algorithm: z.TSIGAlgorithm.ValueString(),
+		Secret:    z.TSIGSecret.ValueString(),
+	}, diagnostics
+}
@@ -0,0 +1,124 @@
+package dns
+
+import (
+	rSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type ImportedRecord struct {
+	Id    types.String `tfsdk:"id"`
+	Type  types.String `tfsdk:"type"`
+	Name  types.String `tfsdk:"name"`
+	Value types.String `tfsdk:"value"`
+	TTL   types.Int64  `tfsdk:"ttl"`
+}
+
+type ZoneImport struct {
+	Id            types.String     `tfsdk:"id"`
+	ZoneName      types.String     `tfsdk:"zone_name"`
+	Nameserver    types.String     `tfsdk:"nameserver"`
+	TSIGKeyName   types.String     `tfsdk:"tsig_key_name"`
+	TSIGAlgorithm types.String     `tfsdk:"tsig_algorithm"`
+	TSIGSecret    types.String     `tfsdk:"tsig_secret"`
+	ZoneId        types.String     `tfsdk:"zone_id"`
+	Records       []ImportedRecord `tfsdk:"records"`
+}
+
+var ZoneImportResourceSchema = rSchema.Schema{
+	MarkdownDescription: "Hetzner Zone Import Resource. Transfers a zone from an external nameserver with AXFR and optionally uploads the records to a Hetzner zone. " +
+		"The transfer runs at creation time only, uploaded records are not managed by Terraform and are kept when the resource is destroyed.",
+	Attributes: map[string]rSchema.Attribute{
+		"id": rSchema.StringAttribute{
+			MarkdownDescription: "Import Identifier",
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"zone_name": rSchema.StringAttribute{
+			MarkdownDescription: "Name of the zone to transfer",
+			Required:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"nameserver": rSchema.StringAttribute{
+			MarkdownDescription: "Nameserver allowing zone transfers, as `host` or `host:port`. Port defaults to `53`.",
+			Required:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"tsig_key_name": rSchema.StringAttribute{
+			MarkdownDescription: "Optional TSIG key name used to sign the transfer request",
+			Optional:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"tsig_algorithm": rSchema.StringAttribute{
+			MarkdownDescription: "TSIG algorithm, e.g. `hmac-sha256`. Required with `tsig_key_name`.",
+			Optional:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"tsig_secret": rSchema.StringAttribute{
+			MarkdownDescription: "Base64 encoded TSIG secret. Required with `tsig_key_name`.",
+			Optional:            true,
+			Sensitive:           true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"zone_id": rSchema.StringAttribute{
+			MarkdownDescription: "Optional Hetzner zone identifier to upload the transferred records to. `SOA` and apex `NS` records are not uploaded.",
+			Optional:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"records": rSchema.ListNestedAttribute{
+			MarkdownDescription: "Transferred records.",
+			Computed:            true,
+			PlanModifiers: []planmodifier.List{
+				listplanmodifier.UseStateForUnknown(),
+			},
+			NestedObject: rSchema.NestedAttributeObject{
+				Attributes: map[string]rSchema.Attribute{
+					"id": rSchema.StringAttribute{
+						MarkdownDescription: "Identifier of the uploaded record",
+						Computed:            true,
+					},
+					"type": rSchema.StringAttribute{
+						MarkdownDescription: "Record Type",
+						Computed:            true,
+					},
+					"name": rSchema.StringAttribute{
+						MarkdownDescription: "Record name",
+						Computed:            true,
+					},
+					"value": rSchema.StringAttribute{
+						MarkdownDescription: "Record value",
+						Computed:            true,
+					},
+					"ttl": rSchema.Int64Attribute{
+						MarkdownDescription: "Record TTL",
+						Computed:            true,
+					},
+				},
+			},
+		},
+	},
+}
+
+func (i *ImportedRecord) mapFromRecord(record Record) {
+	i.Id = record.Id
+	i.Type = record.Type
+	i.Name = record.Name.StringValue
+	i.Value = record.Value
+	i.TTL = record.TTL
+}
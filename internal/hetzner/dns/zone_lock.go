@@ -0,0 +1,42 @@
+package dns
+
+import (
+	"context"
+	"sync"
+)
+
+// zoneLocks orders the writes within a zone, while writes to different zones
+// run in parallel. Waiting for a lock is aborted with the context.
+type zoneLocks struct {
+	mu    sync.Mutex
+	zones map[string]chan struct{}
+}
+
+func newZoneLocks(enabled bool) *zoneLocks {
+	if !enabled {
+		return nil
+	}
+	return &zoneLocks{zones: map[string]chan struct{}{}}
+}
+
+// lock acquires the lock of the zone and returns the function releasing it.
+// A nil zoneLocks does not lock.
+func (l *zoneLocks) lock(ctx context.Context, zoneId string) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	l.mu.Lock()
+	zone, ok := l.zones[zoneId]
+	if !ok {
+		zone = make(chan struct{}, 1)
+		l.zones[zoneId] = zone
+	}
+	l.mu.Unlock()
+
+	select {
+	case zone <- struct{}{}:
+		return func() { <-zone }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
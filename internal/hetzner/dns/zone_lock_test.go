@@ -0,0 +1,107 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/dns/dnstest"
+)
+
+func TestZoneLocksOrderWritesWithinZone(t *testing.T) {
+	locks := newZoneLocks(true)
+	ctx := context.Background()
+
+	unlock, _ := locks.lock(ctx, "zone1")
+	other, err := locks.lock(ctx, "zone2")
+	if err != nil {
+		t.Fatalf("other zones should not be locked: %v", err)
+	}
+	other()
+
+	acquired := make(chan struct{})
+	go func() {
+		release, _ := locks.lock(ctx, "zone1")
+		close(acquired)
+		release()
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("zone should be locked")
+	case <-time.After(20 * time.Millisecond):
+	}
+	unlock()
+	<-acquired
+}
+
+func TestZoneLocksAbortWithContext(t *testing.T) {
+	locks := newZoneLocks(true)
+	unlock, _ := locks.lock(context.Background(), "zone1")
+	defer unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := locks.lock(ctx, "zone1"); err != context.DeadlineExceeded {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestRecordServiceSerializesZoneWrites(t *testing.T) {
+	server := dnstest.NewServer(t)
+	services, _ := NewClient(Config{Token: dnstest.Token, Endpoint: server.URL, SerializeZoneWrites: true}, http.DefaultClient)
+	zone := server.AddZone("example.com", 3600)
+	server.InjectFault(dnstest.Fault{Method: http.MethodPost, Path: "/records", Times: -1, Latency: 20 * time.Millisecond})
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for _, value := range []string{"192.0.2.1", "192.0.2.2", "192.0.2.3"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if diags := services.RecordService().Create(context.Background(), newTestRecord(zone.Id, "A", "www", value, 300)); diags.HasError() {
+				t.Errorf("create failed: %v", diags)
+			}
+		}()
+	}
+	wg.Wait()
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("writes within a zone should be ordered, took %s", elapsed)
+	}
+	if records := server.Records(zone.Id); len(records) != 7 {
+		t.Errorf("expected 7 records, got %d", len(records))
+	}
+}
+
+func TestRecordServiceSerializesReverseZoneWrites(t *testing.T) {
+	server := dnstest.NewServer(t)
+	services, _ := NewClient(Config{Token: dnstest.Token, Endpoint: server.URL, SerializeZoneWrites: true}, http.DefaultClient)
+	reverse := server.AddZone("2.0.192.in-addr.arpa", 3600)
+	server.InjectFault(dnstest.Fault{Method: http.MethodPost, Path: "/records", Times: -1, Latency: 20 * time.Millisecond})
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i, name := range []string{"a.example", "b.example", "c.example"} {
+		zone := server.AddZone(name, 3600)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			record := newTestRecord(zone.Id, "A", "www", fmt.Sprintf("192.0.2.%d", i+1), 300)
+			record.CreatePTR = types.BoolValue(true)
+			if diags := services.RecordService().Create(context.Background(), record); diags.HasError() {
+				t.Errorf("create failed: %v", diags)
+			}
+		}()
+	}
+	wg.Wait()
+	// The records are created in parallel, their PTR records one by one.
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("writes within the reverse zone should be ordered, took %s", elapsed)
+	}
+	if records := server.Records(reverse.Id); len(records) != 7 {
+		t.Errorf("expected 7 records, got %d", len(records))
+	}
+}
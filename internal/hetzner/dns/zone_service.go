@@ -1,61 +1,74 @@
 package dns
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/opsheaven/gohetznerdns"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/api"
 )
 
 type ZoneService interface {
-	List(zones *Zones) diag.Diagnostics
-	Read(zone *Zone) diag.Diagnostics
-	Create(zone *Zone) diag.Diagnostics
-	Update(zone *Zone) diag.Diagnostics
-	Delete(zone *Zone) diag.Diagnostics
+	List(ctx context.Context, zones *Zones) diag.Diagnostics
+	Read(ctx context.Context, zone *Zone) diag.Diagnostics
+	Create(ctx context.Context, zone *ZoneResource) diag.Diagnostics
+	Update(ctx context.Context, zone *Zone) diag.Diagnostics
+	Delete(ctx context.Context, zone *Zone) diag.Diagnostics
 }
 
 type zoneServiceImpl struct {
-	client gohetznerdns.ZoneService
+	client        *zoneClient
+	records       RecordService
+	recordsClient *recordClient
+	consistency   *consistencyWaiter
 }
 
 var _ ZoneService = &zoneServiceImpl{}
 
-func newZoneService(service gohetznerdns.ZoneService) ZoneService {
-	return &zoneServiceImpl{client: service}
+func newZoneService(service *zoneClient, records RecordService, recordsClient *recordClient, consistency *consistencyWaiter) ZoneService {
+	return &zoneServiceImpl{client: service, records: records, recordsClient: recordsClient, consistency: consistency}
 }
 
-func (s *zoneServiceImpl) List(zones *Zones) diag.Diagnostics {
+func (s *zoneServiceImpl) List(ctx context.Context, zones *Zones) diag.Diagnostics {
 	diagnostics := diag.Diagnostics{}
 	var hetznerZones []*gohetznerdns.Zone
 	var apiError error
 
 	if !zones.Name.IsNull() {
-		hetznerZones, apiError = s.client.GetAllZonesByName(zones.Name.ValueStringPointer())
+		name, diags := NewDomainNameValue(zones.Name.ValueString()).ASCIIValue()
+		if diags.HasError() {
+			return diags
+		}
+		hetznerZones, apiError = s.client.GetAllZonesByName(ctx, &name)
 	} else {
-		hetznerZones, apiError = s.client.GetAllZones()
+		hetznerZones, apiError = s.client.GetAllZones(ctx)
 	}
 	if apiError != nil {
-		diagnostics.AddError("Hetzer Client Error", apiError.Error())
+		api.AddError(&diagnostics, apiError)
 	} else {
 		diagnostics.Append(zones.mapFromHetznerZones(hetznerZones)...)
 	}
 	return diagnostics
 }
 
-func (s *zoneServiceImpl) Read(zone *Zone) diag.Diagnostics {
+func (s *zoneServiceImpl) Read(ctx context.Context, zone *Zone) diag.Diagnostics {
 	diagnostics := diag.Diagnostics{}
 	if !zone.Id.IsNull() && zone.Id.String() != "" {
-		hetznerZone, err := s.client.GetZoneById(zone.Id.ValueStringPointer())
+		hetznerZone, err := s.client.GetZoneById(ctx, zone.Id.ValueStringPointer())
 		if err != nil {
-			diagnostics.AddError("Hetzer Client Error", err.Error())
+			api.AddError(&diagnostics, err)
 		} else {
 			zone.mapFromHetznerZone(hetznerZone)
 		}
 	} else if !zone.Name.IsNull() && zone.Name.String() != "" {
-		hetznerZones, err := s.client.GetAllZonesByName(zone.Name.ValueStringPointer())
+		name, diags := zone.Name.ASCIIValue()
+		if diags.HasError() {
+			return diags
+		}
+		hetznerZones, err := s.client.GetAllZonesByName(ctx, &name)
 		if err != nil {
-			diagnostics.AddError("Hetzer Client Error", err.Error())
+			api.AddError(&diagnostics, err)
 		} else if len(hetznerZones) == 0 {
 			diagnostics.AddError("Invalid Zone Name", fmt.Sprintf("Zone with %s can not be found", zone.Name.String()))
 		} else if len(hetznerZones) > 1 {
@@ -70,35 +83,56 @@ func (s *zoneServiceImpl) Read(zone *Zone) diag.Diagnostics {
 	return diagnostics
 }
 
-func (s *zoneServiceImpl) Create(zone *Zone) diag.Diagnostics {
+func (s *zoneServiceImpl) Create(ctx context.Context, zone *ZoneResource) diag.Diagnostics {
 	diagnostics := diag.Diagnostics{}
 	ttl := int(zone.TTL.ValueInt64())
-	hetznerZone, err := s.client.CreateZone(&gohetznerdns.ZoneRequest{Name: zone.Name.ValueStringPointer(), TTL: &ttl})
+	name, diags := zone.Name.ASCIIValue()
+	if diags.HasError() {
+		return diags
+	}
+	rules, diags := compileRewriteRules(zone.CopyRewriteRules)
+	if diags.HasError() {
+		return diags
+	}
+	hetznerZone, err := s.client.CreateZone(ctx, &gohetznerdns.ZoneRequest{Name: &name, TTL: &ttl})
 	if err != nil {
-		diagnostics.AddError("Hetzer Client Error", err.Error())
+		api.AddError(&diagnostics, err)
 	} else {
 		diagnostics.Append(zone.mapFromHetznerZone(hetznerZone)...)
+		if err := s.consistency.waitForZone(ctx, s.client, hetznerZone); err != nil {
+			api.AddError(&diagnostics, err)
+		}
+		if !zone.CopyRecordsFromZoneId.IsNull() {
+			diagnostics.Append(s.copyRecords(ctx, zone, rules)...)
+		}
 	}
 	return diagnostics
 }
 
-func (s *zoneServiceImpl) Update(zone *Zone) diag.Diagnostics {
+func (s *zoneServiceImpl) Update(ctx context.Context, zone *Zone) diag.Diagnostics {
 	diagnostics := diag.Diagnostics{}
 	ttl := int(zone.TTL.ValueInt64())
-	hetznerZone, err := s.client.UpdateZone(zone.Id.ValueStringPointer(), &gohetznerdns.ZoneRequest{Name: zone.Name.ValueStringPointer(), TTL: &ttl})
+	name, diags := zone.Name.ASCIIValue()
+	if diags.HasError() {
+		return diags
+	}
+	hetznerZone, err := s.client.UpdateZone(ctx, zone.Id.ValueStringPointer(), &gohetznerdns.ZoneRequest{Name: &name, TTL: &ttl})
 	if err != nil {
-		diagnostics.AddError("Hetzer Client Error", err.Error())
+		api.AddError(&diagnostics, err)
 	} else {
 		diagnostics.Append(zone.mapFromHetznerZone(hetznerZone)...)
+		if err := s.consistency.waitForZone(ctx, s.client, hetznerZone); err != nil {
+			api.AddError(&diagnostics, err)
+		}
 	}
 	return diagnostics
 }
 
-func (s *zoneServiceImpl) Delete(zone *Zone) diag.Diagnostics {
+func (s *zoneServiceImpl) Delete(ctx context.Context, zone *Zone) diag.Diagnostics {
 	diagnostics := diag.Diagnostics{}
-	err := s.client.DeleteZone(zone.Id.ValueStringPointer())
+	err := s.client.DeleteZone(ctx, zone.Id.ValueStringPointer())
 	if err != nil {
-		diagnostics.AddError("Hetzer Client Error", err.Error())
+		api.AddError(&diagnostics, err)
 	}
 	return diagnostics
 }
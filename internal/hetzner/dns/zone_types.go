@@ -4,6 +4,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	dsSchema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	rSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -12,12 +13,20 @@ import (
 )
 
 type Zone struct {
-	Id     types.String `tfsdk:"id"`
-	Name   types.String `tfsdk:"name"`
-	NS     types.List   `tfsdk:"ns"`
-	Paused types.Bool   `tfsdk:"paused"`
-	Status types.String `tfsdk:"status"`
-	TTL    types.Int64  `tfsdk:"ttl"`
+	Id          types.String `tfsdk:"id"`
+	Name        DomainName   `tfsdk:"name"`
+	NameUnicode types.String `tfsdk:"name_unicode"`
+	NS          types.List   `tfsdk:"ns"`
+	Paused      types.Bool   `tfsdk:"paused"`
+	Status      types.String `tfsdk:"status"`
+	TTL         types.Int64  `tfsdk:"ttl"`
+}
+
+// ZoneResource holds the attributes only managed by the zone resource.
+type ZoneResource struct {
+	Zone
+	CopyRecordsFromZoneId types.String  `tfsdk:"copy_records_from_zone_id"`
+	CopyRewriteRules      []RewriteRule `tfsdk:"copy_rewrite_rules"`
 }
 
 type Zones struct {
@@ -33,8 +42,13 @@ var ZoneDataSourceSchema = dsSchema.Schema{
 			Optional:            true,
 		},
 		"name": dsSchema.StringAttribute{
-			MarkdownDescription: "Zone Name",
+			MarkdownDescription: "Zone Name. Internationalized names can be given either in unicode or punycode.",
 			Optional:            true,
+			CustomType:          DomainNameType{},
+		},
+		"name_unicode": dsSchema.StringAttribute{
+			MarkdownDescription: "Zone Name in unicode representation",
+			Computed:            true,
 		},
 		"ns": dsSchema.ListAttribute{
 			MarkdownDescription: "Primary Nameservers assigned to the Zone. Managed by Hetzner.",
@@ -71,8 +85,16 @@ var ZoneResourceSchema = rSchema.Schema{
 			},
 		},
 		"name": rSchema.StringAttribute{
-			MarkdownDescription: "Zone Name",
+			MarkdownDescription: "Zone Name. Internationalized names can be given either in unicode or punycode.",
 			Required:            true,
+			CustomType:          DomainNameType{},
+		},
+		"name_unicode": rSchema.StringAttribute{
+			MarkdownDescription: "Zone Name in unicode representation",
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				unicodeNameFromName{},
+			},
 		},
 		"ns": rSchema.ListAttribute{
 			MarkdownDescription: "Primary Nameservers assigned to the Zone. Managed by Hetzner.",
@@ -93,11 +115,40 @@ var ZoneResourceSchema = rSchema.Schema{
 			Computed: true,
 		},
 		"ttl": rSchema.Int64Attribute{
-			MarkdownDescription: "Zone Default TTL for zone records",
-			Required:            true,
+			MarkdownDescription: "Zone Default TTL for zone records. Defaults to `dns_ttl` of the provider `defaults` block.",
+			Optional:            true,
+			Computed:            true,
+		},
+		"copy_records_from_zone_id": rSchema.StringAttribute{
+			MarkdownDescription: "Seeds the zone with all records except `SOA` and `NS` of the given zone at creation time. Copied records are not managed by Terraform, and later changes of the attribute are ignored.",
+			Optional:            true,
+		},
+		"copy_rewrite_rules": rSchema.ListNestedAttribute{
+			MarkdownDescription: "Rewrite rules applied in order to the names and values of the copied records.",
+			Optional:            true,
+			NestedObject: rSchema.NestedAttributeObject{
+				Attributes: map[string]rSchema.Attribute{
+					"pattern": rSchema.StringAttribute{
+						MarkdownDescription: "Regular expression matching the part of the record name or value to rewrite",
+						Required:            true,
+					},
+					"replacement": rSchema.StringAttribute{
+						MarkdownDescription: "Replacement text. Capture groups of the pattern can be referenced as `${1}`.",
+						Required:            true,
+					},
+				},
+			},
 		},
 	},
 }
+
+// ZoneResourceFieldPaths maps the fields of Hetzner API validation errors to
+// the attributes of ZoneResourceSchema.
+var ZoneResourceFieldPaths = map[string]path.Path{
+	"name": path.Root("name"),
+	"ttl":  path.Root("ttl"),
+}
+
 var ZonesDataSourceSchema = dsSchema.Schema{
 	MarkdownDescription: "Hetzner Zones Data Source.",
 	Attributes: map[string]dsSchema.Attribute{
@@ -120,7 +171,8 @@ func (z *Zone) mapFromHetznerZone(zone *gohetznerdns.Zone) diag.Diagnostics {
 	if z.Id.IsNull() || z.Id.IsUnknown() {
 		z.Id = types.StringValue(*zone.Id)
 	}
-	z.Name = types.StringValue(*zone.Name)
+	z.Name = NewDomainNameValue(*zone.Name)
+	z.NameUnicode = z.Name.UnicodeValue()
 	z.Paused = types.BoolValue(*zone.Paused)
 	z.Status = types.StringValue(*zone.Status)
 	z.TTL = types.Int64Value(int64(*zone.TTL))
@@ -0,0 +1,158 @@
+package hetzner
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	logSubsystem = "hetzner_api"
+	// maxLoggedBodySize limits the part of response bodies written to the
+	// logs.
+	maxLoggedBodySize = 64 * 1024
+)
+
+// secretHeaders are never written to the logs.
+var secretHeaders = []string{"Auth-API-Token", "Authorization", "Proxy-Authorization"}
+
+// loggingTransport logs every Hetzner API request with the tflog subsystem
+// hetzner_api. Summaries are logged at DEBUG, headers and bodies at TRACE.
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+var _ http.RoundTripper = &loggingTransport{}
+
+func newLoggingTransport(next http.RoundTripper) http.RoundTripper {
+	return &loggingTransport{next: next}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := newLogContext(req)
+	fields := map[string]interface{}{
+		"http_method": req.Method,
+		"http_path":   req.URL.Path,
+	}
+
+	traceFields := headerFields("http_request_header_", req.Header)
+	if body := requestBody(req); body != "" {
+		traceFields["http_request_body"] = body
+	}
+	tflog.SubsystemTrace(ctx, logSubsystem, "Sending API request", fields, traceFields)
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	fields["latency_ms"] = time.Since(start).Milliseconds()
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemDebug(ctx, logSubsystem, "API request failed", fields)
+		return resp, err
+	}
+
+	fields["http_status"] = resp.StatusCode
+	if requestId := resp.Header.Get("X-Request-Id"); requestId != "" {
+		fields["request_id"] = requestId
+	}
+	tflog.SubsystemDebug(ctx, logSubsystem, "Received API response", fields)
+
+	resp.Body = &loggedBody{
+		ReadCloser:  resp.Body,
+		ctx:         ctx,
+		fields:      fields,
+		traceFields: headerFields("http_response_header_", resp.Header),
+	}
+	return resp, nil
+}
+
+// loggedBody logs the response body at TRACE once it is read to the end or
+// closed. The body is copied while the client reads it, so it is never read
+// ahead of the client, and at most maxLoggedBodySize bytes are kept.
+type loggedBody struct {
+	io.ReadCloser
+	ctx         context.Context
+	fields      map[string]interface{}
+	traceFields map[string]interface{}
+	data        bytes.Buffer
+	truncated   bool
+	logged      bool
+}
+
+func (b *loggedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if remaining := maxLoggedBodySize - b.data.Len(); n > remaining {
+		b.data.Write(p[:remaining])
+		b.truncated = true
+	} else {
+		b.data.Write(p[:n])
+	}
+	if err == io.EOF {
+		b.log()
+	}
+	return n, err
+}
+
+func (b *loggedBody) Close() error {
+	b.log()
+	return b.ReadCloser.Close()
+}
+
+func (b *loggedBody) log() {
+	if b.logged {
+		return
+	}
+	b.logged = true
+	b.traceFields["http_response_body"] = b.data.String()
+	if b.truncated {
+		b.traceFields["http_response_body_truncated"] = true
+	}
+	tflog.SubsystemTrace(b.ctx, logSubsystem, "Received API response body", b.fields, b.traceFields)
+}
+
+func newLogContext(req *http.Request) context.Context {
+	ctx := tflog.NewSubsystem(req.Context(), logSubsystem)
+	keys := []string{}
+	for _, header := range secretHeaders {
+		keys = append(keys, headerFieldKey("http_request_header_", header))
+		if value := req.Header.Get(header); value != "" {
+			// Also masks secrets echoed in bodies or error messages.
+			ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, logSubsystem, value)
+		}
+	}
+	return tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, logSubsystem, keys...)
+}
+
+func headerFields(prefix string, header http.Header) map[string]interface{} {
+	fields := map[string]interface{}{}
+	for key := range header {
+		fields[headerFieldKey(prefix, key)] = header.Get(key)
+	}
+	return fields
+}
+
+func headerFieldKey(prefix, header string) string {
+	return prefix + strings.ReplaceAll(strings.ToLower(header), "-", "_")
+}
+
+// requestBody reads a copy of the request body, leaving the body itself
+// untouched for the next transport.
+func requestBody(req *http.Request) string {
+	if req.Body == nil || req.GetBody == nil {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
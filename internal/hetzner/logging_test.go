@@ -0,0 +1,89 @@
+package hetzner
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestLoggingTransportMasksToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "request-1")
+		w.Write([]byte(`{"zone":{"name":"example.com"}}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/zones", strings.NewReader(`{"name":"example.com"}`))
+	req.Header.Set("Auth-API-Token", "secret-token")
+
+	resp, err := (&http.Client{Transport: newLoggingTransport(http.DefaultTransport)}).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 log entries, got %d", len(entries))
+	}
+	debug := entries[1]
+	if debug["http_method"] != "POST" || debug["http_path"] != "/zones" || debug["http_status"] != float64(200) || debug["request_id"] != "request-1" {
+		t.Errorf("unexpected debug entry %v", debug)
+	}
+	if entries[2]["http_response_body"] != `{"zone":{"name":"example.com"}}` {
+		t.Errorf("response body should be logged at trace, got %v", entries[2])
+	}
+	if strings.Contains(output.String(), "secret-token") || entries[0]["http_request_header_auth_api_token"] != "***" {
+		t.Errorf("token should be masked, got %v", entries[0])
+	}
+}
+
+func TestLoggingTransportStreamsResponseBodies(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(bytes.Repeat([]byte("a"), maxLoggedBodySize))
+		w.(http.Flusher).Flush()
+		<-release
+		w.Write([]byte("b"))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/records", nil)
+
+	// The response is returned before the server completes the body.
+	resp, err := (&http.Client{Transport: newLoggingTransport(http.DefaultTransport)}).Do(req)
+	close(release)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil || len(body) != maxLoggedBodySize+1 {
+		t.Fatalf("body should be read completely, got %d bytes: %v", len(body), err)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 log entries, got %d", len(entries))
+	}
+	if logged, _ := entries[2]["http_response_body"].(string); len(logged) != maxLoggedBodySize || entries[2]["http_response_body_truncated"] != true {
+		t.Errorf("logged body should be truncated, got %d bytes and %v", len(logged), entries[2]["http_response_body_truncated"])
+	}
+}
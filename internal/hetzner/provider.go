@@ -1,6 +1,10 @@
 package hetzner
 
 import (
+	"net/http"
+	"sync"
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/dns"
 )
@@ -8,42 +12,122 @@ import (
 type ProviderContext struct {
 	DnsApiEnabled bool
 	DnsApiToken   string
+	MaxRetries    int
+	RetryWaitMin  time.Duration
+	RetryWaitMax  time.Duration
+
+	DnsApiTokenSource string
+	DnsApiEndpoint    string
+	RequestsPerSecond float64
+	CacheTTL          time.Duration
+
+	SerializeZoneWrites bool
+	ConsistencyTimeout  time.Duration
+
+	UserAgent string
+
+	HTTPProxy             string
+	CABundleFile          string
+	InsecureSkipVerify    bool
+	ClientCertificateFile string
+	ClientKeyFile         string
+
+	Project  string
+	Defaults Defaults
 }
 
 type Provider interface {
 	DNSServices() (dns.DNSServices, diag.Diagnostics)
+	Project() string
+	Defaults() Defaults
 }
 
+// ProviderFactory creates the Provider of a configured provider instance.
+// NewProvider is the default, tests may inject fake services instead.
+type ProviderFactory func(ctx *ProviderContext) (Provider, diag.Diagnostics)
+
+var _ ProviderFactory = NewProvider
+
+// provider creates the API clients lazily on first use, so configurations
+// not using an API do not need its credentials.
 type provider struct {
-	context     *ProviderContext
-	dnsServices dns.DNSServices
+	context    *ProviderContext
+	httpClient *http.Client
+
+	dnsOnce        sync.Once
+	dnsServices    dns.DNSServices
+	dnsDiagnostics diag.Diagnostics
 }
 
 var _ Provider = &provider{}
 
 func NewProvider(ctx *ProviderContext) (Provider, diag.Diagnostics) {
 	diagnostics := diag.Diagnostics{}
-	provider := &provider{context: ctx}
-
-	if ctx.DnsApiEnabled {
-		dns, diags := dns.NewClient(ctx.DnsApiToken)
-		diagnostics.Append(diags...)
-		if !diagnostics.HasError() {
-			provider.dnsServices = dns
-		} else {
-			diagnostics.AddWarning("DNS Api Disabled", "DNS Service has initialization errors.")
-		}
-	} else {
+	httpClient, err := newHTTPClient(ctx)
+	if err != nil {
+		diagnostics.AddError("Invalid Hetzner HTTP Transport Configuration", err.Error())
+		return nil, diagnostics
+	}
+	provider := &provider{context: ctx, httpClient: httpClient}
+
+	if !ctx.DnsApiEnabled {
 		diagnostics.AddWarning("DNS Api Disabled", "DNS Service is disabled and all DNS calls will generate error")
 	}
 	return provider, diagnostics
 }
 
+// newHTTPClient creates the HTTP client shared by all Hetzner API clients.
+func newHTTPClient(ctx *ProviderContext) (*http.Client, error) {
+	transport, err := newBaseTransport(ctx)
+	if err != nil {
+		return nil, err
+	}
+	transport = newLoggingTransport(transport)
+	transport = newRateLimitTransport(transport, ctx.RequestsPerSecond)
+	transport = newRetryTransport(transport, ctx.MaxRetries, ctx.RetryWaitMin, ctx.RetryWaitMax)
+	transport = newUserAgentTransport(transport, ctx.UserAgent)
+	return &http.Client{Transport: transport}, nil
+}
+
 func (p *provider) DNSServices() (dns.DNSServices, diag.Diagnostics) {
 	diagnostics := diag.Diagnostics{}
-	if p.context.DnsApiEnabled {
-		return p.dnsServices, diagnostics
+	if !p.context.DnsApiEnabled {
+		diagnostics.AddError("DNS Api Disabled", "DNS Api is disabled, please enable on the provider")
+		return nil, diagnostics
 	}
-	diagnostics.AddError("DNS Api Disabled", "DNS Api is disabled, please enable on the provider")
-	return nil, diagnostics
+	p.dnsOnce.Do(func() {
+		p.dnsServices, p.dnsDiagnostics = p.newDNSServices()
+	})
+	return p.dnsServices, p.dnsDiagnostics
+}
+
+func (p *provider) Project() string {
+	return p.context.Project
+}
+
+func (p *provider) Defaults() Defaults {
+	return p.context.Defaults
+}
+
+func (p *provider) newDNSServices() (dns.DNSServices, diag.Diagnostics) {
+	if p.context.DnsApiToken == "" {
+		diagnostics := diag.Diagnostics{}
+		diagnostics.AddError(
+			"Missing Hetzner DNS API Token",
+			"The provider cannot create the Hetzner DNS API client as there is a missing or empty value for the Hetzner DNS API token. "+
+				"Set the dns_api_token value in the provider configuration or use the HETZNER_DNS_API_TOKEN environment variable. "+
+				"Alternatively configure dns_api_token_file, dns_api_token_command or a profile of the ~/.config/hetzner/credentials file. "+
+				"To disable DNS Api lookups, disable DNS API by configuring `dns_api_enabled=false`",
+		)
+		return nil, diagnostics
+	}
+	return dns.NewClient(dns.Config{
+		Token:       p.context.DnsApiToken,
+		TokenSource: p.context.DnsApiTokenSource,
+		Endpoint:    p.context.DnsApiEndpoint,
+		CacheTTL:    p.context.CacheTTL,
+
+		SerializeZoneWrites: p.context.SerializeZoneWrites,
+		ConsistencyTimeout:  p.context.ConsistencyTimeout,
+	}, p.httpClient)
 }
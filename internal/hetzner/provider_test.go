@@ -0,0 +1,189 @@
+package hetzner
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/dns"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/dns/dnstest"
+)
+
+func newTestDNSServices(t *testing.T, server *dnstest.Server) dns.DNSServices {
+	t.Helper()
+	provider, diags := NewProvider(&ProviderContext{
+		DnsApiEnabled:  true,
+		DnsApiToken:    dnstest.Token,
+		DnsApiEndpoint: server.URL,
+		MaxRetries:     3,
+		RetryWaitMin:   time.Millisecond,
+		RetryWaitMax:   10 * time.Millisecond,
+	})
+	if diags.HasError() {
+		t.Fatalf("provider initialization failed: %v", diags)
+	}
+	services, diags := provider.DNSServices()
+	if diags.HasError() {
+		t.Fatalf("DNS services are not available: %v", diags)
+	}
+	return services
+}
+
+func newTestZone(name string) *dns.ZoneResource {
+	return &dns.ZoneResource{Zone: dns.Zone{Id: types.StringNull(), Name: dns.NewDomainNameValue(name), TTL: types.Int64Value(3600)}, CopyRecordsFromZoneId: types.StringNull()}
+}
+
+func TestProviderRetriesRateLimitedRequests(t *testing.T) {
+	server := dnstest.NewServer(t)
+	services := newTestDNSServices(t, server)
+	server.InjectFault(dnstest.Fault{Method: http.MethodPost, Path: "/zones", Times: 2, StatusCode: http.StatusTooManyRequests, RetryAfter: "0"})
+
+	zone := newTestZone("example.com")
+	if diags := services.ZoneService().Create(context.Background(), zone); diags.HasError() {
+		t.Fatalf("rate limited create should be retried: %v", diags)
+	}
+	if zones := server.Zones(); len(zones) != 1 || zones[0].Id != zone.Id.ValueString() {
+		t.Errorf("expected exactly the created zone, got %v", zones)
+	}
+}
+
+func TestProviderRecoversFromServerErrorsAndDroppedConnections(t *testing.T) {
+	server := dnstest.NewServer(t)
+	services := newTestDNSServices(t, server)
+	created := server.AddZone("example.com", 3600)
+	server.InjectFault(dnstest.Fault{Method: http.MethodGet, Path: "/zones/", Call: 2, StatusCode: http.StatusInternalServerError})
+	server.InjectFault(dnstest.Fault{Method: http.MethodGet, Path: "/zones/", Call: 3, Drop: true})
+
+	for i := 0; i < 3; i++ {
+		zone := &dns.Zone{Id: types.StringValue(created.Id)}
+		if diags := services.ZoneService().Read(context.Background(), zone); diags.HasError() {
+			t.Fatalf("read %d should recover: %v", i, diags)
+		}
+	}
+}
+
+func TestProviderDoesNotRetryFailedCreates(t *testing.T) {
+	server := dnstest.NewServer(t)
+	services := newTestDNSServices(t, server)
+	zone := server.AddZone("example.com", 3600)
+	server.InjectFault(dnstest.Fault{Method: http.MethodPost, Path: "/records", StatusCode: http.StatusInternalServerError})
+
+	record := &dns.RecordResource{
+		Record: dns.Record{
+			Type:          types.StringValue("A"),
+			ZoneId:        types.StringValue(zone.Id),
+			Name:          dns.NewDomainNameValue("www"),
+			Value:         types.StringValue("192.0.2.1"),
+			TTL:           types.Int64Value(300),
+			Owner:         types.StringNull(),
+			OwnerRecordId: types.StringNull(),
+		},
+		CreatePTR:   types.BoolValue(false),
+		PTRRecordId: types.StringNull(),
+	}
+	if diags := services.RecordService().Create(context.Background(), record); !diags.HasError() {
+		t.Fatal("failed create should be reported")
+	}
+	if records := server.Records(zone.Id); len(records) != 4 {
+		t.Errorf("no record should be created, got %v", records)
+	}
+}
+
+func TestProviderCancelsSlowRequests(t *testing.T) {
+	server := dnstest.NewServer(t)
+	services := newTestDNSServices(t, server)
+	server.InjectFault(dnstest.Fault{Latency: time.Minute})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if diags := services.ZoneService().List(ctx, &dns.Zones{Name: types.StringNull()}); !diags.HasError() {
+		t.Error("cancelled request should fail")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("cancelled request should stop waiting, waited %s", elapsed)
+	}
+}
+
+func TestProviderReportsPartialPTRFailures(t *testing.T) {
+	server := dnstest.NewServer(t)
+	services := newTestDNSServices(t, server)
+	zone := server.AddZone("example.com", 3600)
+	for _, value := range []string{"192.0.2.1", "192.0.2.2", "192.0.2.3"} {
+		server.AddRecord(zone.Id, "A", "www", value, nil)
+	}
+	reverseZone := server.AddZone("2.0.192.in-addr.arpa", 3600)
+	server.InjectFault(dnstest.Fault{Method: http.MethodPost, Path: "/records", Call: 2, StatusCode: http.StatusInternalServerError})
+
+	records := &dns.ReverseRecords{ZoneId: types.StringValue(zone.Id)}
+	diags := services.ReverseRecordService().Create(context.Background(), records)
+	if !diags.HasError() {
+		t.Error("failed PTR creation should be reported")
+	}
+	if len(records.Records) != 2 || records.Id.ValueString() != zone.Id {
+		t.Errorf("state should track the 2 created PTR records, got %+v", records)
+	}
+	ptrs := 0
+	for _, record := range server.Records(reverseZone.Id) {
+		if record.Type == "PTR" {
+			ptrs++
+		}
+	}
+	if ptrs != 2 {
+		t.Errorf("expected 2 PTR records, got %d", ptrs)
+	}
+}
+
+func TestProviderReportsPartialBulkFailures(t *testing.T) {
+	server := dnstest.NewServer(t)
+	services := newTestDNSServices(t, server)
+	source := server.AddZone("example.com", 3600)
+	server.AddRecord(source.Id, "A", "www", "192.0.2.1", nil)
+	server.AddRecord(source.Id, "A", "mail", "192.0.2.9", nil)
+	server.InjectFault(dnstest.Fault{Method: http.MethodPost, Path: "/records/bulk", StatusCode: http.StatusTooManyRequests, RetryAfter: "0"})
+
+	zone := newTestZone("example.org")
+	zone.CopyRecordsFromZoneId = types.StringValue(source.Id)
+	zone.CopyRewriteRules = []dns.RewriteRule{{Pattern: types.StringValue(`^192\.0\.2\.9$`), Replacement: types.StringValue("")}}
+	diags := services.ZoneService().Create(context.Background(), zone)
+	if !diags.HasError() || diags[0].Summary() != "Record Not Copied" {
+		t.Errorf("rejected record should be reported, got %v", diags)
+	}
+	if zone.Id.IsNull() {
+		t.Fatal("created zone should be tracked")
+	}
+	copied := []string{}
+	for _, record := range server.Records(zone.Id.ValueString()) {
+		if record.Type == "A" {
+			copied = append(copied, record.Name)
+		}
+	}
+	if len(copied) != 1 || copied[0] != "www" {
+		t.Errorf("valid records should be copied, got %v", copied)
+	}
+}
+
+func TestProviderInitializesDNSServicesOnFirstUse(t *testing.T) {
+	provider, diags := NewProvider(&ProviderContext{DnsApiEnabled: true})
+	if diags.HasError() {
+		t.Fatalf("provider without token should be configured: %v", diags)
+	}
+	for i := 0; i < 2; i++ {
+		services, diags := provider.DNSServices()
+		if services != nil || !diags.HasError() || diags[0].Summary() != "Missing Hetzner DNS API Token" {
+			t.Errorf("expected missing token error, got %v", diags)
+		}
+	}
+}
+
+func TestProviderReusesDNSServices(t *testing.T) {
+	server := dnstest.NewServer(t)
+	provider, _ := NewProvider(&ProviderContext{DnsApiEnabled: true, DnsApiToken: dnstest.Token, DnsApiEndpoint: server.URL})
+	first, _ := provider.DNSServices()
+	second, _ := provider.DNSServices()
+	if first == nil || first != second {
+		t.Error("DNS services should be created once")
+	}
+}
@@ -0,0 +1,40 @@
+package hetzner
+
+import (
+	"math"
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
+const DefaultRequestsPerSecond = 10
+
+// rateLimitTransport throttles all requests of a provider instance with a
+// shared token bucket, so parallel resource operations stay under the API
+// quota instead of being rate limited by Hetzner.
+type rateLimitTransport struct {
+	next    http.RoundTripper
+	limiter *rate.Limiter
+}
+
+var _ http.RoundTripper = &rateLimitTransport{}
+
+// newRateLimitTransport returns next unchanged when requestsPerSecond is not
+// positive, which disables rate limiting.
+func newRateLimitTransport(next http.RoundTripper, requestsPerSecond float64) http.RoundTripper {
+	if requestsPerSecond <= 0 {
+		return next
+	}
+	burst := int(math.Max(1, math.Ceil(requestsPerSecond)))
+	return &rateLimitTransport{
+		next:    next,
+		limiter: rate.NewLimiter(rate.Limit(requestsPerSecond), burst),
+	}
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.next.RoundTrip(req)
+}
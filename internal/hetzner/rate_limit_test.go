@@ -0,0 +1,62 @@
+package hetzner
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimitTransportThrottlesParallelRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	client := &http.Client{Transport: newRateLimitTransport(http.DefaultTransport, 20)}
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 40; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	// 20 requests are served by the initial burst, the rest at 20 per second.
+	if elapsed := time.Since(start); elapsed < 900*time.Millisecond {
+		t.Errorf("40 requests at 20 requests per second finished in %s", elapsed)
+	}
+}
+
+func TestRateLimitTransportStopsWaitingOnCancellation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	client := &http.Client{Transport: newRateLimitTransport(http.DefaultTransport, 0.1)}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if _, err := client.Do(req); err == nil {
+		t.Error("request exceeding the rate limit should fail when its context is done")
+	}
+}
+
+func TestRateLimitTransportDisabled(t *testing.T) {
+	if transport := newRateLimitTransport(http.DefaultTransport, 0); transport != http.DefaultTransport {
+		t.Error("rate limiting should be disabled when requests per second is not positive")
+	}
+}
@@ -0,0 +1,129 @@
+package hetzner
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultMaxRetries   = 5
+	DefaultRetryWaitMin = 1 * time.Second
+	DefaultRetryWaitMax = 30 * time.Second
+)
+
+// retryTransport retries Hetzner API requests failing with rate limits,
+// transient server errors or connection errors. Rate limited requests are
+// rejected by the API and are always safe to retry, other failures are only
+// retried for idempotent methods.
+type retryTransport struct {
+	next         http.RoundTripper
+	maxRetries   int
+	retryWaitMin time.Duration
+	retryWaitMax time.Duration
+}
+
+var _ http.RoundTripper = &retryTransport{}
+
+func newRetryTransport(next http.RoundTripper, maxRetries int, retryWaitMin, retryWaitMax time.Duration) http.RoundTripper {
+	return &retryTransport{
+		next:         next,
+		maxRetries:   maxRetries,
+		retryWaitMin: retryWaitMin,
+		retryWaitMax: retryWaitMax,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		// RoundTrippers must not modify the request, retries send a clone
+		// with a fresh body.
+		attemptReq := req
+		if attempt > 0 {
+			attemptReq = req.Clone(req.Context())
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				attemptReq.Body = body
+			}
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+		if attempt >= t.maxRetries || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if !isIdempotent(req.Method) {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode >= http.StatusInternalServerError && resp.StatusCode != http.StatusNotImplemented
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// backoff returns the wait duration before the next attempt. The Retry-After
+// header of the response takes precedence over the exponential backoff, and
+// both are capped by the maximum wait duration.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return min(max(wait, t.retryWaitMin), t.retryWaitMax)
+		}
+	}
+
+	wait := float64(t.retryWaitMin) * math.Pow(2, float64(attempt))
+	if wait > float64(t.retryWaitMax) {
+		wait = float64(t.retryWaitMax)
+	}
+	// Equal jitter spreads retries of parallel requests apart.
+	jittered := time.Duration(wait/2 + rand.Float64()*wait/2)
+	return max(jittered, t.retryWaitMin)
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
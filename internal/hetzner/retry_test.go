@@ -0,0 +1,165 @@
+package hetzner
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestRetryClient(maxRetries int) *http.Client {
+	return &http.Client{Transport: newRetryTransport(http.DefaultTransport, maxRetries, time.Millisecond, 10*time.Millisecond)}
+}
+
+// newStatusServer responds with the given status codes in order, and with
+// 200 OK once they are exhausted.
+func newStatusServer(t *testing.T, headers http.Header, statusCodes ...int) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := int(atomic.AddInt32(&calls, 1))
+		body, _ := io.ReadAll(r.Body)
+		if call <= len(statusCodes) {
+			for key, values := range headers {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(statusCodes[call-1])
+			return
+		}
+		w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestRetryTransportRetriesRateLimitedRequests(t *testing.T) {
+	server, calls := newStatusServer(t, http.Header{"Retry-After": []string{"0"}}, http.StatusTooManyRequests, http.StatusTooManyRequests)
+
+	resp, err := newTestRetryClient(3).Post(server.URL, "application/json", strings.NewReader(`{"name":"example.com"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK || *calls != 3 {
+		t.Errorf("expected success after 3 calls, got %d after %d calls", resp.StatusCode, *calls)
+	}
+	if string(body) != `{"name":"example.com"}` {
+		t.Errorf("request body should be replayed on retry, got %q", body)
+	}
+}
+
+func TestRetryTransportDoesNotModifyTheRequest(t *testing.T) {
+	server, calls := newStatusServer(t, http.Header{"Retry-After": []string{"0"}}, http.StatusTooManyRequests)
+
+	req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("{}"))
+	body := req.Body
+	resp, err := newRetryTransport(http.DefaultTransport, 3, time.Millisecond, 10*time.Millisecond).RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || *calls != 2 {
+		t.Errorf("expected success after 2 calls, got %d after %d calls", resp.StatusCode, *calls)
+	}
+	if req.Body != body {
+		t.Error("retries should not replace the body of the request")
+	}
+}
+
+func TestRetryTransportRetriesServerErrorsOfIdempotentRequests(t *testing.T) {
+	server, calls := newStatusServer(t, nil, http.StatusBadGateway, http.StatusServiceUnavailable)
+
+	resp, err := newTestRetryClient(3).Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || *calls != 3 {
+		t.Errorf("expected success after 3 calls, got %d after %d calls", resp.StatusCode, *calls)
+	}
+}
+
+func TestRetryTransportDoesNotRetryServerErrorsOfNonIdempotentRequests(t *testing.T) {
+	server, calls := newStatusServer(t, nil, http.StatusInternalServerError)
+
+	resp, err := newTestRetryClient(3).Post(server.URL, "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusInternalServerError || *calls != 1 {
+		t.Errorf("expected no retry, got %d after %d calls", resp.StatusCode, *calls)
+	}
+}
+
+func TestRetryTransportDoesNotRetryClientErrors(t *testing.T) {
+	server, calls := newStatusServer(t, nil, http.StatusNotFound)
+
+	resp, err := newTestRetryClient(3).Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound || *calls != 1 {
+		t.Errorf("expected no retry, got %d after %d calls", resp.StatusCode, *calls)
+	}
+}
+
+func TestRetryTransportGivesUpAfterMaxRetries(t *testing.T) {
+	server, calls := newStatusServer(t, nil, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+
+	resp, err := newTestRetryClient(2).Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable || *calls != 3 {
+		t.Errorf("expected last failure after 3 calls, got %d after %d calls", resp.StatusCode, *calls)
+	}
+}
+
+func TestRetryTransportStopsWaitingOnCancellation(t *testing.T) {
+	server, _ := newStatusServer(t, http.Header{"Retry-After": []string{"60"}}, http.StatusTooManyRequests)
+	client := &http.Client{Transport: newRetryTransport(http.DefaultTransport, 3, time.Millisecond, time.Minute)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+	start := time.Now()
+	if _, err := client.Do(req); err == nil {
+		t.Error("cancelled request should fail")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("cancelled request should stop waiting, waited %s", elapsed)
+	}
+}
+
+func TestRetryTransportBackoff(t *testing.T) {
+	transport := &retryTransport{retryWaitMin: time.Second, retryWaitMax: 10 * time.Second}
+
+	for attempt, limit := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second} {
+		wait := transport.backoff(attempt, nil)
+		if wait < time.Second || wait < limit/2 || wait > limit {
+			t.Errorf("backoff of attempt %d is %s, expected between %s and %s", attempt, wait, limit/2, limit)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"5"}}}
+	if wait := transport.backoff(0, resp); wait != 5*time.Second {
+		t.Errorf("Retry-After should be respected, got %s", wait)
+	}
+	resp.Header.Set("Retry-After", "120")
+	if wait := transport.backoff(0, resp); wait != 10*time.Second {
+		t.Errorf("Retry-After should be capped by the maximum wait, got %s", wait)
+	}
+	resp.Header.Set("Retry-After", time.Now().Add(3*time.Second).UTC().Format(http.TimeFormat))
+	if wait := transport.backoff(0, resp); wait < time.Second || wait > 3*time.Second {
+		t.Errorf("Retry-After date should be respected, got %s", wait)
+	}
+}
@@ -0,0 +1,55 @@
+package hetzner
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// newBaseTransport returns the transport sending the API requests. Without
+// http_proxy the proxy of the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment
+// variables is used, the CA bundle extends the system certificates.
+func newBaseTransport(config *ProviderContext) (http.RoundTripper, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if config.HTTPProxy != "" {
+		proxy, err := url.Parse(config.HTTPProxy)
+		if err != nil {
+			// The parse error quotes the URL including its credentials.
+			return nil, errors.New("http_proxy is not a valid URL")
+		}
+		if (proxy.Scheme != "http" && proxy.Scheme != "https" && proxy.Scheme != "socks5") || proxy.Host == "" {
+			return nil, fmt.Errorf("http_proxy %q must be an absolute http, https or socks5 URL", proxy.Redacted())
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12, InsecureSkipVerify: config.InsecureSkipVerify}
+	if config.CABundleFile != "" {
+		pem, err := os.ReadFile(config.CABundleFile)
+		if err != nil {
+			return nil, fmt.Errorf("unreadable ca_bundle_file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("ca_bundle_file %s contains no PEM certificates", config.CABundleFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if config.ClientCertificateFile != "" || config.ClientKeyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(config.ClientCertificateFile, config.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
+}
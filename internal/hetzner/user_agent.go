@@ -0,0 +1,43 @@
+package hetzner
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// UserAgent returns the User-Agent of the API requests, e.g.
+// `terraform-provider-hetzner/1.0.0 terraform/1.9.0 <suffix>`.
+func UserAgent(providerVersion, terraformVersion, suffix string) string {
+	userAgent := fmt.Sprintf("terraform-provider-hetzner/%s", providerVersion)
+	if terraformVersion != "" {
+		userAgent += fmt.Sprintf(" terraform/%s", terraformVersion)
+	}
+	if suffix = strings.TrimSpace(suffix); suffix != "" {
+		userAgent += " " + suffix
+	}
+	return userAgent
+}
+
+// userAgentTransport sets the User-Agent of all requests, so the traffic of
+// a provider instance can be attributed.
+type userAgentTransport struct {
+	next      http.RoundTripper
+	userAgent string
+}
+
+var _ http.RoundTripper = &userAgentTransport{}
+
+// newUserAgentTransport returns next unchanged for an empty userAgent.
+func newUserAgentTransport(next http.RoundTripper, userAgent string) http.RoundTripper {
+	if userAgent == "" {
+		return next
+	}
+	return &userAgentTransport{next: next, userAgent: userAgent}
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,42 @@
+package hetzner
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUserAgent(t *testing.T) {
+	for _, test := range []struct {
+		terraformVersion, suffix, expected string
+	}{
+		{"1.9.0", "", "terraform-provider-hetzner/1.0.0 terraform/1.9.0"},
+		{"1.9.0", " workspace/production ", "terraform-provider-hetzner/1.0.0 terraform/1.9.0 workspace/production"},
+		{"", "", "terraform-provider-hetzner/1.0.0"},
+	} {
+		if userAgent := UserAgent("1.0.0", test.terraformVersion, test.suffix); userAgent != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, userAgent)
+		}
+	}
+}
+
+func TestUserAgentTransportSetsHeader(t *testing.T) {
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.UserAgent()
+	}))
+	defer server.Close()
+	client, err := newHTTPClient(&ProviderContext{UserAgent: "terraform-provider-hetzner/test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if userAgent != "terraform-provider-hetzner/test" {
+		t.Errorf("unexpected User-Agent %q", userAgent)
+	}
+}
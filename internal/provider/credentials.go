@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const defaultProfile = "default"
+
+// credentialsFile returns the path of the credentials file holding the API
+// tokens of named profiles.
+func credentialsFile() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "hetzner", "credentials"), nil
+}
+
+// resolveDNSApiToken returns the DNS API token and a description of where it
+// was configured. Explicitly configured sources exclude each other and take
+// precedence over the HETZNER_DNS_API_TOKEN environment variable, which takes
+// precedence over the profile of the HETZNER_PROFILE environment variable or
+// the default profile.
+func resolveDNSApiToken(ctx context.Context, config hetznerProviderModel, diagnostics *diag.Diagnostics) (string, string) {
+	configured := []string{}
+	for name, value := range map[string]bool{
+		"dns_api_token":         !config.DnsApiToken.IsNull(),
+		"dns_api_token_file":    !config.DnsApiTokenFile.IsNull(),
+		"dns_api_token_command": !config.DnsApiTokenCommand.IsNull(),
+		"profile":               !config.Profile.IsNull(),
+	} {
+		if value {
+			configured = append(configured, name)
+		}
+	}
+	if len(configured) > 1 {
+		slices.Sort(configured)
+		diagnostics.AddError("Conflicting Hetzner DNS API Token Sources",
+			fmt.Sprintf("Only one of dns_api_token, dns_api_token_file, dns_api_token_command and profile can be configured, got %s.", strings.Join(configured, ", ")))
+		return "", ""
+	}
+
+	switch {
+	case !config.DnsApiToken.IsNull():
+		return config.DnsApiToken.ValueString(), "the dns_api_token provider attribute"
+	case !config.DnsApiTokenFile.IsNull():
+		return readTokenFile(config.DnsApiTokenFile.ValueString(), diagnostics), fmt.Sprintf("the dns_api_token_file %s", config.DnsApiTokenFile.ValueString())
+	case !config.DnsApiTokenCommand.IsNull():
+		return runTokenCommand(ctx, config.DnsApiTokenCommand, diagnostics), "the dns_api_token_command provider attribute"
+	case !config.Profile.IsNull():
+		return readProfileToken(config.Profile.ValueString(), true, diagnostics)
+	}
+
+	if token := os.Getenv("HETZNER_DNS_API_TOKEN"); token != "" {
+		return token, "the HETZNER_DNS_API_TOKEN environment variable"
+	}
+	if profile := os.Getenv("HETZNER_PROFILE"); profile != "" {
+		return readProfileToken(profile, true, diagnostics)
+	}
+	return readProfileToken(defaultProfile, false, diagnostics)
+}
+
+func readTokenFile(name string, diagnostics *diag.Diagnostics) string {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		diagnostics.AddAttributeError(path.Root("dns_api_token_file"), "Unreadable Hetzner DNS API Token File", err.Error())
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func runTokenCommand(ctx context.Context, command types.List, diagnostics *diag.Diagnostics) string {
+	args := []string{}
+	diagnostics.Append(command.ElementsAs(ctx, &args, false)...)
+	if len(args) == 0 {
+		diagnostics.AddAttributeError(path.Root("dns_api_token_command"), "Invalid Hetzner DNS API Token Command", "dns_api_token_command must contain the command to run.")
+		return ""
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		diagnostics.AddAttributeError(path.Root("dns_api_token_command"), "Failed Hetzner DNS API Token Command",
+			fmt.Sprintf("%s failed: %s\n\n%s", args[0], err, strings.TrimSpace(stderr.String())))
+		return ""
+	}
+	return strings.TrimSpace(stdout.String())
+}
+
+// readProfileToken reads the dns_api_token of the profile from the INI style
+// credentials file:
+//
+//	[default]
+//	dns_api_token = <token>
+//
+// A missing file or profile is only an error when required.
+func readProfileToken(profile string, required bool, diagnostics *diag.Diagnostics) (string, string) {
+	name, err := credentialsFile()
+	if err != nil {
+		if required {
+			diagnostics.AddAttributeError(path.Root("profile"), "Unreadable Hetzner Credentials File", err.Error())
+		}
+		return "", ""
+	}
+	source := fmt.Sprintf("the profile %q of %s", profile, name)
+
+	file, err := os.Open(name)
+	if err != nil {
+		if required || !errors.Is(err, fs.ErrNotExist) {
+			diagnostics.AddAttributeError(path.Root("profile"), "Unreadable Hetzner Credentials File", err.Error())
+		}
+		return "", source
+	}
+	defer file.Close()
+
+	profiles, err := parseCredentials(file)
+	if err != nil {
+		diagnostics.AddAttributeError(path.Root("profile"), "Invalid Hetzner Credentials File", fmt.Sprintf("%s: %s", name, err))
+		return "", source
+	}
+	token, ok := profiles[profile]["dns_api_token"]
+	if !ok && required {
+		diagnostics.AddAttributeError(path.Root("profile"), "Missing Hetzner Credentials Profile",
+			fmt.Sprintf("%s has no dns_api_token in profile %q.", name, profile))
+	}
+	return token, source
+}
+
+func parseCredentials(reader io.Reader) (map[string]map[string]string, error) {
+	profiles := map[string]map[string]string{}
+	var section map[string]string
+	scanner := bufio.NewScanner(reader)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, ";"):
+		case strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]"):
+			name := strings.TrimSpace(text[1 : len(text)-1])
+			if profiles[name] == nil {
+				profiles[name] = map[string]string{}
+			}
+			section = profiles[name]
+		default:
+			key, value, ok := strings.Cut(text, "=")
+			if !ok || section == nil {
+				return nil, fmt.Errorf("line %d is neither a [profile] nor a key = value pair of a profile", line)
+			}
+			section[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return profiles, scanner.Err()
+}
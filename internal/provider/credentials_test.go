@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const testCredentials = `
+# Hetzner credentials
+[default]
+dns_api_token = default-token
+
+[staging]
+dns_api_token = staging-token
+`
+
+func setupCredentials(t *testing.T) string {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("HETZNER_DNS_API_TOKEN", "")
+	t.Setenv("HETZNER_PROFILE", "")
+	if err := os.MkdirAll(filepath.Join(home, ".config", "hetzner"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".config", "hetzner", "credentials"), []byte(testCredentials), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return home
+}
+
+func newTestProviderModel() hetznerProviderModel {
+	return hetznerProviderModel{
+		DnsApiToken:        types.StringNull(),
+		DnsApiTokenFile:    types.StringNull(),
+		DnsApiTokenCommand: types.ListNull(types.StringType),
+		Profile:            types.StringNull(),
+	}
+}
+
+func TestResolveDNSApiToken(t *testing.T) {
+	home := setupCredentials(t)
+	tokenFile := filepath.Join(home, "token")
+	os.WriteFile(tokenFile, []byte("file-token\n"), 0o600)
+
+	tests := map[string]struct {
+		configure func(*hetznerProviderModel)
+		env       map[string]string
+		token     string
+		source    string
+	}{
+		"attribute": {
+			configure: func(m *hetznerProviderModel) { m.DnsApiToken = types.StringValue("attribute-token") },
+			env:       map[string]string{"HETZNER_DNS_API_TOKEN": "env-token"},
+			token:     "attribute-token",
+			source:    "dns_api_token provider attribute",
+		},
+		"file": {
+			configure: func(m *hetznerProviderModel) { m.DnsApiTokenFile = types.StringValue(tokenFile) },
+			token:     "file-token",
+			source:    tokenFile,
+		},
+		"command": {
+			configure: func(m *hetznerProviderModel) {
+				m.DnsApiTokenCommand = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("echo"), types.StringValue("command-token")})
+			},
+			token:  "command-token",
+			source: "dns_api_token_command",
+		},
+		"profile": {
+			configure: func(m *hetznerProviderModel) { m.Profile = types.StringValue("staging") },
+			env:       map[string]string{"HETZNER_DNS_API_TOKEN": "env-token"},
+			token:     "staging-token",
+			source:    `profile "staging"`,
+		},
+		"environment": {
+			env:    map[string]string{"HETZNER_DNS_API_TOKEN": "env-token", "HETZNER_PROFILE": "staging"},
+			token:  "env-token",
+			source: "HETZNER_DNS_API_TOKEN",
+		},
+		"environment profile": {
+			env:    map[string]string{"HETZNER_PROFILE": "staging"},
+			token:  "staging-token",
+			source: `profile "staging"`,
+		},
+		"default profile": {
+			token:  "default-token",
+			source: `profile "default"`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range test.env {
+				t.Setenv(key, value)
+			}
+			config := newTestProviderModel()
+			if test.configure != nil {
+				test.configure(&config)
+			}
+			diagnostics := diag.Diagnostics{}
+			token, source := resolveDNSApiToken(context.Background(), config, &diagnostics)
+			if diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", diagnostics)
+			}
+			if token != test.token || !strings.Contains(source, test.source) {
+				t.Errorf("expected %s from %s, got %s from %s", test.token, test.source, token, source)
+			}
+		})
+	}
+}
+
+func TestResolveDNSApiTokenErrors(t *testing.T) {
+	setupCredentials(t)
+
+	tests := map[string]func(*hetznerProviderModel){
+		"conflicting sources": func(m *hetznerProviderModel) {
+			m.DnsApiToken = types.StringValue("token")
+			m.Profile = types.StringValue("staging")
+		},
+		"missing file": func(m *hetznerProviderModel) { m.DnsApiTokenFile = types.StringValue("/nonexistent/token") },
+		"failing command": func(m *hetznerProviderModel) {
+			m.DnsApiTokenCommand = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("false")})
+		},
+		"missing profile": func(m *hetznerProviderModel) { m.Profile = types.StringValue("production") },
+	}
+	for name, configure := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := newTestProviderModel()
+			configure(&config)
+			diagnostics := diag.Diagnostics{}
+			resolveDNSApiToken(context.Background(), config, &diagnostics)
+			if !diagnostics.HasError() {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestResolveDNSApiTokenWithoutCredentialsFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("HETZNER_DNS_API_TOKEN", "")
+	t.Setenv("HETZNER_PROFILE", "")
+
+	diagnostics := diag.Diagnostics{}
+	token, _ := resolveDNSApiToken(context.Background(), newTestProviderModel(), &diagnostics)
+	if token != "" || diagnostics.HasError() {
+		t.Errorf("missing credentials file should resolve no token without errors, got %q %v", token, diagnostics)
+	}
+}
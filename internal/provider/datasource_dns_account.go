@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/dns"
+)
+
+var (
+	_ datasource.DataSource              = &dnsAccountDataSource{}
+	_ datasource.DataSourceWithConfigure = &dnsAccountDataSource{}
+)
+
+type dnsAccountDataSource struct {
+	Service dns.AccountService
+}
+
+func NewAccountDataSource() datasource.DataSource {
+	return &dnsAccountDataSource{}
+}
+
+func (datasource *dnsAccountDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	dataProvider, ok := req.ProviderData.(hetzner.Provider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *hetznerDataProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+	} else {
+		service, diags := dataProvider.DNSServices()
+		resp.Diagnostics.Append(diags...)
+		if !resp.Diagnostics.HasError() {
+			datasource.Service = service.AccountService()
+		}
+	}
+}
+
+func (datasource *dnsAccountDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_account"
+}
+
+func (datasource *dnsAccountDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = dns.AccountDataSourceSchema
+}
+
+func (datasource *dnsAccountDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state dns.Account
+	diags := req.Config.Get(ctx, &state)
+
+	diags.Append(datasource.Service.Read(ctx, &state)...)
+	diags.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDNSAccountDataSource(t *testing.T) {
+	server := testAccServer(t)
+	server.AddZone("example.com", 3600)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "hetzner_dns_account" "current" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.hetzner_dns_account.current", "valid", "true"),
+					resource.TestCheckResourceAttr("data.hetzner_dns_account.current", "zone_count", "1"),
+					resource.TestCheckResourceAttr("data.hetzner_dns_account.current", "record_count", "4"),
+					resource.TestCheckResourceAttr("data.hetzner_dns_account.current", "token_source", "the HETZNER_DNS_API_TOKEN environment variable"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDNSAccountDataSourceInvalidToken(t *testing.T) {
+	testAccServer(t)
+	t.Setenv("HETZNER_DNS_API_TOKEN", "invalid")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "hetzner_dns_account" "current" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.hetzner_dns_account.current", "valid", "false"),
+					resource.TestCheckNoResourceAttr("data.hetzner_dns_account.current", "zone_count"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDNSRecordsDataSource(t *testing.T) {
+	server := testAccServer(t)
+	zone := server.AddZone("example.com", 3600)
+	for i := 0; i < 120; i++ {
+		server.AddRecord(zone.Id, "A", fmt.Sprintf("host%d", i), "192.0.2.1", nil)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "hetzner_dns_records" "test" {
+  zone_id = %q
+}
+`, zone.Id),
+				// 120 records across two pages plus the SOA and NS records.
+				Check: resource.TestCheckResourceAttr("data.hetzner_dns_records.test", "records.#", "124"),
+			},
+		},
+	})
+}
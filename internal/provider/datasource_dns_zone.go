@@ -53,7 +53,7 @@ func (d *dnsZoneDataSource) Schema(_ context.Context, req datasource.SchemaReque
 func (datasource *dnsZoneDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state dns.Zone
 	diags := req.Config.Get(ctx, &state)
-	diags.Append(datasource.Service.Read(&state)...)
+	diags.Append(datasource.Service.Read(ctx, &state)...)
 	diags.Append(resp.State.Set(ctx, &state)...)
 	resp.Diagnostics.Append(diags...)
 }
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDNSZoneDataSource(t *testing.T) {
+	server := testAccServer(t)
+	zone := server.AddZone("example.com", 3600)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "hetzner_dns_zone" "test" {
+  name = "example.com"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.hetzner_dns_zone.test", "id", zone.Id),
+					resource.TestCheckResourceAttr("data.hetzner_dns_zone.test", "ttl", "3600"),
+					resource.TestCheckResourceAttr("data.hetzner_dns_zone.test", "ns.#", "3"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+data "hetzner_dns_zone" "test" {
+  id = %q
+}
+`, zone.Id),
+				Check: resource.TestCheckResourceAttr("data.hetzner_dns_zone.test", "name", "example.com"),
+			},
+		},
+	})
+}
@@ -53,7 +53,7 @@ func (datasource *dnsZonesDataSource) Read(ctx context.Context, req datasource.R
 	var state dns.Zones
 	diags := req.Config.Get(ctx, &state)
 
-	diags.Append(datasource.Service.List(&state)...)
+	diags.Append(datasource.Service.List(ctx, &state)...)
 	diags.Append(resp.State.Set(ctx, &state)...)
 	resp.Diagnostics.Append(diags...)
 }
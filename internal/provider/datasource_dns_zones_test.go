@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDNSZonesDataSource(t *testing.T) {
+	server := testAccServer(t)
+	server.AddZone("example.com", 3600)
+	server.AddZone("example.org", 3600)
+	server.AddZone("2.0.192.in-addr.arpa", 3600)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "hetzner_dns_zones" "all" {}
+
+data "hetzner_dns_zones" "example" {
+  name = "example"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.hetzner_dns_zones.all", "zones.#", "3"),
+					resource.TestCheckResourceAttr("data.hetzner_dns_zones.example", "zones.#", "2"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner"
+)
+
+type providerDefaultsModel struct {
+	DnsTTL types.Int64 `tfsdk:"dns_ttl"`
+}
+
+var defaultsBlock = schema.SingleNestedBlock{
+	MarkdownDescription: "Optional default values of unset resource attributes. The effective values are shown in the plan.",
+	Attributes: map[string]schema.Attribute{
+		"dns_ttl": schema.Int64Attribute{
+			MarkdownDescription: "Optional TTL of `hetzner_dns_zone` and `hetzner_dns_record` resources without `ttl`.",
+			Optional:            true,
+		},
+	},
+}
+
+func (m *providerDefaultsModel) toDefaults(diagnostics *diag.Diagnostics) hetzner.Defaults {
+	defaults := hetzner.Defaults{}
+	if m == nil {
+		return defaults
+	}
+	if !m.DnsTTL.IsNull() && !m.DnsTTL.IsUnknown() {
+		ttl := m.DnsTTL.ValueInt64()
+		if ttl <= 0 {
+			diagnostics.AddAttributeError(path.Root("defaults").AtName("dns_ttl"), "Invalid Default TTL", "dns_ttl must be positive.")
+		}
+		defaults.DnsTTL = &ttl
+	}
+	return defaults
+}
+
+// planDefaultTTL plans the default TTL of resources without configured ttl.
+func planDefaultTTL(ctx context.Context, defaults hetzner.Defaults, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var ttl types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ttl"), &ttl)...)
+	if resp.Diagnostics.HasError() || !ttl.IsNull() {
+		return
+	}
+	if defaults.DnsTTL == nil {
+		resp.Diagnostics.AddAttributeError(path.Root("ttl"), "Missing TTL", "ttl must be configured unless the provider configures dns_ttl in its defaults block.")
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("ttl"), types.Int64Value(*defaults.DnsTTL))...)
+}
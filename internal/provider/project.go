@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// withProject adds the project attribute recording the project of the
+// provider managing the resource.
+func withProject(s schema.Schema) schema.Schema {
+	attributes := map[string]schema.Attribute{}
+	maps.Copy(attributes, s.Attributes)
+	attributes["project"] = schema.StringAttribute{
+		MarkdownDescription: "Project of the provider managing the resource, see `project` of the provider.",
+		Computed:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+	s.Attributes = attributes
+	return s
+}
+
+func projectValue(project string) types.String {
+	if project == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(project)
+}
+
+// checkProject returns the project of the resource state, which must be the
+// project of the provider. States without project, e.g. of imported
+// resources, adopt the project of the provider.
+func checkProject(project string, state types.String, diagnostics *diag.Diagnostics) types.String {
+	if state.IsNull() || state.IsUnknown() {
+		return projectValue(project)
+	}
+	if state.ValueString() != project {
+		diagnostics.AddAttributeError(
+			path.Root("project"),
+			"Resource Of Another Project",
+			fmt.Sprintf("The resource belongs to project %q, but the provider is configured for project %q. "+
+				"The resource may have been moved to another provider alias. Use the provider of the resource project, "+
+				"or remove the resource from the state and import it with the provider of the new project.", state.ValueString(), project),
+		)
+	}
+	return state
+}
+
+// importProjectState imports the resource of the import ID <id> or
+// <project>/<id>. The project of prefixed IDs must be the project of the
+// provider, so that a resource is not imported by the provider alias of
+// another project. The id attribute never contains the project.
+func importProjectState(ctx context.Context, project string, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := req.ID
+	if importProject, importId, ok := strings.Cut(req.ID, "/"); ok {
+		if importProject != project {
+			resp.Diagnostics.AddError(
+				"Resource Of Another Project",
+				fmt.Sprintf("The import ID %q names project %q, but the provider is configured for project %q. "+
+					"Import the resource with the provider of its project.", req.ID, importProject, project),
+			)
+			return
+		}
+		id = importId
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project"), projectValue(project))...)
+}
@@ -0,0 +1,256 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/api"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/dns"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/dns/dnstest"
+)
+
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"hetzner": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+// testAccServer starts a fake Hetzner DNS API and points the provider at it,
+// so acceptance tests run offline.
+func testAccServer(t *testing.T) *dnstest.Server {
+	server := dnstest.NewServer(t)
+	t.Setenv("HETZNER_DNS_API_TOKEN", dnstest.Token)
+	t.Setenv("HETZNER_DNS_API_ENDPOINT", server.URL)
+	return server
+}
+
+// testAccProviderConfig retries quickly, so fault injection tests stay fast.
+const testAccProviderConfig = `
+provider "hetzner" {
+  retry_wait_min = "1ms"
+  retry_wait_max = "10ms"
+}
+`
+
+type fakeProvider struct {
+	services dns.DNSServices
+	project  string
+	defaults hetzner.Defaults
+}
+
+func (p *fakeProvider) DNSServices() (dns.DNSServices, diag.Diagnostics) {
+	return p.services, nil
+}
+
+func (p *fakeProvider) Project() string {
+	return p.project
+}
+
+func (p *fakeProvider) Defaults() hetzner.Defaults {
+	return p.defaults
+}
+
+type fakeDNSServices struct {
+	zones    dns.ZoneService
+	records  dns.RecordService
+	reverse  dns.ReverseRecordService
+	imports  dns.ZoneImportService
+	accounts dns.AccountService
+}
+
+func (s *fakeDNSServices) ZoneService() dns.ZoneService                   { return s.zones }
+func (s *fakeDNSServices) RecordService() dns.RecordService               { return s.records }
+func (s *fakeDNSServices) ReverseRecordService() dns.ReverseRecordService { return s.reverse }
+func (s *fakeDNSServices) ZoneImportService() dns.ZoneImportService       { return s.imports }
+func (s *fakeDNSServices) AccountService() dns.AccountService             { return s.accounts }
+
+// testProviderModel returns a provider configuration with a static token.
+func testProviderModel() hetznerProviderModel {
+	model := newTestProviderModel()
+	model.DnsApiToken = types.StringValue("token")
+	return model
+}
+
+// configureTestProvider configures the provider with the given fake services
+// injected. The context passed to the provider factory is returned with the
+// response.
+func configureTestProvider(t *testing.T, model hetznerProviderModel, services dns.DNSServices) (*provider.ConfigureResponse, *hetzner.ProviderContext) {
+	t.Helper()
+	ctx := context.Background()
+	var providerContext *hetzner.ProviderContext
+	p := NewWithProviderFactory("test", func(c *hetzner.ProviderContext) (hetzner.Provider, diag.Diagnostics) {
+		providerContext = c
+		return &fakeProvider{services: services}, nil
+	})()
+
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+	config := tfsdk.Plan{Schema: schemaResp.Schema}
+	if diags := config.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("invalid provider configuration: %v", diags)
+	}
+	configureResp := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{TerraformVersion: "1.9.0", Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw}}, configureResp)
+	return configureResp, providerContext
+}
+
+// configureTestResource configures the resource through the provider with
+// the given fake services injected.
+func configureTestResource(t *testing.T, r resource.ResourceWithConfigure, services dns.DNSServices) {
+	t.Helper()
+	configureResp, _ := configureTestProvider(t, testProviderModel(), services)
+	diags := configureResp.Diagnostics
+
+	resourceResp := &resource.ConfigureResponse{}
+	r.Configure(context.Background(), resource.ConfigureRequest{ProviderData: configureResp.ResourceData}, resourceResp)
+	diags.Append(resourceResp.Diagnostics...)
+	if diags.HasError() {
+		t.Fatalf("resource configuration failed: %v", diags)
+	}
+}
+
+type fakeAccountService struct {
+	validations int
+	diags       diag.Diagnostics
+}
+
+func (s *fakeAccountService) Validate(context.Context) diag.Diagnostics {
+	s.validations++
+	return s.diags
+}
+
+func (s *fakeAccountService) Read(context.Context, *dns.Account) diag.Diagnostics {
+	return s.diags
+}
+
+func TestProviderValidatesCredentials(t *testing.T) {
+	accounts := &fakeAccountService{}
+	accounts.diags.AddError("Hetzner API Authentication Failed", "invalid token")
+	services := &fakeDNSServices{accounts: accounts}
+
+	if resp, _ := configureTestProvider(t, testProviderModel(), services); resp.Diagnostics.HasError() || accounts.validations != 0 {
+		t.Errorf("credentials should only be validated on demand, got %d validations: %v", accounts.validations, resp.Diagnostics)
+	}
+
+	model := testProviderModel()
+	model.ValidateCredentials = types.BoolValue(true)
+	resp, _ := configureTestProvider(t, model, services)
+	if !resp.Diagnostics.HasError() || accounts.validations != 1 || resp.ResourceData != nil {
+		t.Errorf("invalid credentials should fail the configuration, got %d validations: %v", accounts.validations, resp.Diagnostics)
+	}
+
+	model.DnsApiEnabled = types.BoolValue(false)
+	if resp, _ := configureTestProvider(t, model, services); resp.Diagnostics.HasError() || accounts.validations != 1 {
+		t.Errorf("credentials of disabled APIs should not be validated: %v", resp.Diagnostics)
+	}
+}
+
+func TestProviderSkipsTokenResolutionOfDisabledAPI(t *testing.T) {
+	model := testProviderModel()
+	model.DnsApiToken = types.StringNull()
+	model.DnsApiTokenCommand = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("false")})
+	if resp, _ := configureTestProvider(t, model, &fakeDNSServices{}); !resp.Diagnostics.HasError() {
+		t.Error("failing token commands should fail the configuration")
+	}
+
+	model.DnsApiEnabled = types.BoolValue(false)
+	if resp, _ := configureTestProvider(t, model, &fakeDNSServices{}); resp.Diagnostics.HasError() {
+		t.Errorf("token commands of disabled APIs should not be run: %v", resp.Diagnostics)
+	}
+}
+
+// testResourceSchema returns the schema of a resource with its null state.
+func testResourceSchema(t *testing.T, r resource.Resource) (schema.Schema, tfsdk.State) {
+	t.Helper()
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	return schemaResp.Schema, tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+}
+
+func testPlan(t *testing.T, s schema.Schema, value interface{}) tfsdk.Plan {
+	t.Helper()
+	plan := tfsdk.Plan{Schema: s}
+	if diags := plan.Set(context.Background(), value); diags.HasError() {
+		t.Fatalf("invalid plan: %v", diags)
+	}
+	return plan
+}
+
+func testState(t *testing.T, s schema.Schema, value interface{}) tfsdk.State {
+	t.Helper()
+	state := tfsdk.State{Schema: s}
+	if diags := state.Set(context.Background(), value); diags.HasError() {
+		t.Fatalf("invalid state: %v", diags)
+	}
+	return state
+}
+
+// testReadErrors reads the state of the resource returned by newResource,
+// whose service fails with the given diagnostics. Resources deleted outside of
+// Terraform are removed from state, while other errors are reported.
+func testReadErrors(t *testing.T, newResource func(t *testing.T, diags diag.Diagnostics) (resource.Resource, tfsdk.State)) {
+	for name, test := range map[string]struct {
+		statusCode int
+		removed    bool
+	}{
+		"server error": {statusCode: http.StatusInternalServerError, removed: false},
+		"not found":    {statusCode: http.StatusNotFound, removed: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			diags := diag.Diagnostics{}
+			api.AddError(&diags, api.NewError(test.statusCode, nil, ""))
+			r, state := newResource(t, diags)
+			resp := &resource.ReadResponse{State: state}
+			r.Read(context.Background(), resource.ReadRequest{State: state}, resp)
+			if resp.Diagnostics.HasError() == test.removed || resp.State.Raw.IsNull() != test.removed {
+				t.Errorf("expected removed %t, got state %v: %v", test.removed, resp.State.Raw, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestProviderSetsUserAgent(t *testing.T) {
+	model := testProviderModel()
+	model.UserAgentSuffix = types.StringValue("workspace/production")
+	resp, providerContext := configureTestProvider(t, model, &fakeDNSServices{})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("configure failed: %v", resp.Diagnostics)
+	}
+	if expected := "terraform-provider-hetzner/test terraform/1.9.0 workspace/production"; providerContext.UserAgent != expected {
+		t.Errorf("expected User-Agent %q, got %q", expected, providerContext.UserAgent)
+	}
+}
+
+func TestProviderRequiresClientKey(t *testing.T) {
+	model := testProviderModel()
+	model.ClientCertificateFile = types.StringValue("client.pem")
+	if resp, _ := configureTestProvider(t, model, &fakeDNSServices{}); !resp.Diagnostics.HasError() {
+		t.Error("a client certificate without key should fail the configuration")
+	}
+}
+
+func TestProviderPassesDefaults(t *testing.T) {
+	model := testProviderModel()
+	model.Defaults = &providerDefaultsModel{
+		DnsTTL: types.Int64Value(3600),
+	}
+	resp, providerContext := configureTestProvider(t, model, &fakeDNSServices{})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("configure failed: %v", resp.Diagnostics)
+	}
+	defaults := providerContext.Defaults
+	if defaults.DnsTTL == nil || *defaults.DnsTTL != 3600 {
+		t.Errorf("unexpected defaults %+v", defaults)
+	}
+}
@@ -4,18 +4,29 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/api"
 	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/dns"
 )
 
 var _ resource.Resource = &dnsRecordResource{}
 var _ resource.ResourceWithConfigure = &dnsRecordResource{}
 var _ resource.ResourceWithImportState = &dnsRecordResource{}
+var _ resource.ResourceWithModifyPlan = &dnsRecordResource{}
 
 type dnsRecordResource struct {
-	Service dns.RecordService
+	Service  dns.RecordService
+	Defaults hetzner.Defaults
+	Project  string
+}
+
+type dnsRecordResourceModel struct {
+	dns.RecordResource
+	Project  types.String   `tfsdk:"project"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func NewDnsRecordResource() resource.Resource {
@@ -34,6 +45,8 @@ func (resource *dnsRecordResource) Configure(ctx context.Context, req resource.C
 			fmt.Sprintf("Expected *hetznerDataProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 	} else {
+		resource.Project = dataProvider.Project()
+		resource.Defaults = dataProvider.Defaults()
 		service, diags := dataProvider.DNSServices()
 		resp.Diagnostics.Append(diags...)
 		if !resp.Diagnostics.HasError() {
@@ -47,36 +60,63 @@ func (resource *dnsRecordResource) Metadata(ctx context.Context, req resource.Me
 }
 
 func (resource *dnsRecordResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
-	resp.Schema = dns.RecordResourceSchema
+	resp.Schema = withTimeouts(ctx, withProject(dns.RecordResourceSchema))
+}
+
+func (resource *dnsRecordResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planDefaultTTL(ctx, resource.Defaults, req, resp)
 }
 
 func (resource *dnsRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var state dns.Record
+	var state dnsRecordResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &state)...)
-	resp.Diagnostics.Append(resource.Service.Create(&state)...)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	state.Project = projectValue(resource.Project)
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+	diags := api.WithFieldPaths(resource.Service.Create(ctx, &state.RecordResource), dns.RecordResourceFieldPaths)
+	setCreatedState(ctx, &state, state.Id, diags, resp)
 }
 
 func (resource *dnsRecordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state dns.Record
+	var state dnsRecordResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
-	resource.Service.Read(&state)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	state.Project = checkProject(resource.Project, state.Project, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+	setReadState(ctx, &state, resource.Service.Read(ctx, &state.Record), resp)
 }
 
 func (resource *dnsRecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var state dns.Record
+	var state, prior dnsRecordResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &state)...)
-	resp.Diagnostics.Append(resource.Service.Update(&state)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	state.PTRRecordId = prior.PTRRecordId
+	state.OwnerRecordId = prior.OwnerRecordId
+	state.Project = checkProject(resource.Project, prior.Project, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+	resp.Diagnostics.Append(api.WithFieldPaths(resource.Service.Update(ctx, &state.RecordResource), dns.RecordResourceFieldPaths)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (resource *dnsRecordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state dns.Record
+	var state dnsRecordResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
-	resp.Diagnostics.Append(resource.Service.Delete(&state)...)
+	state.Project = checkProject(resource.Project, state.Project, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+	resp.Diagnostics.Append(resource.Service.Delete(ctx, &state.RecordResource)...)
 }
 
 func (r *dnsRecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importProjectState(ctx, r.Project, req, resp)
 }
@@ -0,0 +1,347 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/api"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/dns"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/dns/dnstest"
+)
+
+func TestAccDNSRecordResource(t *testing.T) {
+	server := testAccServer(t)
+	zone := server.AddZone("example.com", 3600)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDNSRecordConfig(zone.Id, "192.0.2.1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("hetzner_dns_record.test", "id"),
+					resource.TestCheckResourceAttr("hetzner_dns_record.test", "zone_id", zone.Id),
+					resource.TestCheckResourceAttr("hetzner_dns_record.test", "name", "www"),
+					resource.TestCheckResourceAttr("hetzner_dns_record.test", "value", "192.0.2.1"),
+					resource.TestCheckResourceAttr("hetzner_dns_record.test", "owner_record_id", ""),
+				),
+			},
+			{
+				ResourceName:            "hetzner_dns_record.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"create_ptr", "ptr_record_id", "owner", "owner_record_id"},
+			},
+			{
+				Config: testAccDNSRecordConfig(zone.Id, "192.0.2.2"),
+				Check:  resource.TestCheckResourceAttr("hetzner_dns_record.test", "value", "192.0.2.2"),
+			},
+		},
+	})
+}
+
+func TestAccDNSRecordResourceOwnerAndPTR(t *testing.T) {
+	server := testAccServer(t)
+	zone := server.AddZone("example.com", 3600)
+	reverseZone := server.AddZone("2.0.192.in-addr.arpa", 3600)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "hetzner_dns_record" "test" {
+  zone_id    = %q
+  type       = "A"
+  name       = "www"
+  value      = "192.0.2.1"
+  ttl        = 300
+  create_ptr = true
+  owner      = "workspace-a"
+}
+`, zone.Id),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("hetzner_dns_record.test", "ptr_record_id"),
+					resource.TestCheckResourceAttrSet("hetzner_dns_record.test", "owner_record_id"),
+					func(*terraform.State) error {
+						for _, record := range server.Records(reverseZone.Id) {
+							if record.Type == "PTR" && record.Name == "1" {
+								return nil
+							}
+						}
+						return fmt.Errorf("PTR record was not created: %v", server.Records(reverseZone.Id))
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccDNSRecordResourceValidationError(t *testing.T) {
+	server := testAccServer(t)
+	zone := server.AddZone("example.com", 3600)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDNSRecordConfig(zone.Id, ""),
+				ExpectError: regexp.MustCompile("Invalid Hetzner API Request"),
+			},
+		},
+	})
+}
+
+func TestAccDNSRecordResourceRecoversFromFailedCreate(t *testing.T) {
+	server := testAccServer(t)
+	zone := server.AddZone("example.com", 3600)
+	server.InjectFault(dnstest.Fault{Method: http.MethodPost, Path: "/records", StatusCode: http.StatusInternalServerError})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Creates are not idempotent and must not be retried on server errors.
+				Config:      testAccProviderConfig + testAccDNSRecordConfig(zone.Id, "192.0.2.1"),
+				ExpectError: regexp.MustCompile("500 Internal Server Error"),
+			},
+			{
+				Config: testAccProviderConfig + testAccDNSRecordConfig(zone.Id, "192.0.2.1"),
+				Check: func(*terraform.State) error {
+					if records := server.Records(zone.Id); len(records) != 5 {
+						return fmt.Errorf("expected the SOA, NS and one A record, got %v", records)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func testAccDNSRecordConfig(zoneId, value string) string {
+	return fmt.Sprintf(`
+resource "hetzner_dns_record" "test" {
+  zone_id = %q
+  type    = "A"
+  name    = "www"
+  value   = %q
+  ttl     = 300
+}
+`, zoneId, value)
+}
+
+type fakeRecordService struct {
+	diags    diag.Diagnostics
+	deadline time.Time
+	created  []dns.RecordResource
+	updated  []dns.RecordResource
+	deleted  []dns.RecordResource
+}
+
+func (s *fakeRecordService) List(ctx context.Context, records *dns.Records) diag.Diagnostics {
+	return s.diags
+}
+
+func (s *fakeRecordService) Read(ctx context.Context, record *dns.Record) diag.Diagnostics {
+	return s.diags
+}
+
+func (s *fakeRecordService) Create(ctx context.Context, record *dns.RecordResource) diag.Diagnostics {
+	if s.diags.HasError() {
+		return s.diags
+	}
+	s.deadline, _ = ctx.Deadline()
+	record.Id = types.StringValue("record-1")
+	record.PTRRecordId = types.StringNull()
+	record.OwnerRecordId = types.StringNull()
+	s.created = append(s.created, *record)
+	return s.diags
+}
+
+func (s *fakeRecordService) Update(ctx context.Context, record *dns.RecordResource) diag.Diagnostics {
+	s.updated = append(s.updated, *record)
+	return s.diags
+}
+
+func (s *fakeRecordService) Delete(ctx context.Context, record *dns.RecordResource) diag.Diagnostics {
+	s.deleted = append(s.deleted, *record)
+	return s.diags
+}
+
+func newTestRecordResource(t *testing.T, service dns.RecordService) (*dnsRecordResource, schema.Schema, tfsdk.State) {
+	r := NewDnsRecordResource().(*dnsRecordResource)
+	configureTestResource(t, r, &fakeDNSServices{records: service})
+	s, state := testResourceSchema(t, r)
+	return r, s, state
+}
+
+func testRecordModel() dnsRecordResourceModel {
+	return dnsRecordResourceModel{
+		RecordResource: dns.RecordResource{
+			Record: dns.Record{
+				Id:            types.StringUnknown(),
+				Type:          types.StringValue("A"),
+				ZoneId:        types.StringValue("zone-1"),
+				Name:          dns.NewDomainNameValue("www"),
+				NameUnicode:   types.StringValue("www"),
+				Value:         types.StringValue("192.0.2.1"),
+				TTL:           types.Int64Value(300),
+				Owner:         types.StringNull(),
+				OwnerRecordId: types.StringUnknown(),
+			},
+			CreatePTR:   types.BoolValue(false),
+			PTRRecordId: types.StringUnknown(),
+		},
+		Timeouts: testTimeouts(nil),
+	}
+}
+
+func TestDNSRecordResourceCreate(t *testing.T) {
+	service := &fakeRecordService{}
+	r, s, state := newTestRecordResource(t, service)
+
+	resp := &fwresource.CreateResponse{State: state}
+	r.Create(context.Background(), fwresource.CreateRequest{Plan: testPlan(t, s, testRecordModel())}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("create failed: %v", resp.Diagnostics)
+	}
+
+	var created dnsRecordResourceModel
+	resp.State.Get(context.Background(), &created)
+	if created.Id.ValueString() != "record-1" || created.Value.ValueString() != "192.0.2.1" || len(service.created) != 1 {
+		t.Errorf("unexpected state %+v", created)
+	}
+}
+
+func TestDNSRecordResourceCreateFailure(t *testing.T) {
+	service := &fakeRecordService{}
+	service.diags.AddError("Hetzner Resource Conflict", "record exists")
+	r, s, state := newTestRecordResource(t, service)
+
+	resp := &fwresource.CreateResponse{State: state}
+	r.Create(context.Background(), fwresource.CreateRequest{Plan: testPlan(t, s, testRecordModel())}, resp)
+	if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "Hetzner Resource Conflict" {
+		t.Errorf("service diagnostics should be propagated, got %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Errorf("failed create should not store state, got %v", resp.State.Raw)
+	}
+}
+
+func TestDNSRecordResourceCreateAttachesFieldErrors(t *testing.T) {
+	service := &fakeRecordService{}
+	body := `{"error":{"message":"invalid record","details":{"fields":[{"name":"ttl","messages":["must not be negative"]},{"name":"records","messages":["too many"]}]}}}`
+	api.AddError(&service.diags, api.NewError(http.StatusUnprocessableEntity, []byte(body), ""))
+	r, s, state := newTestRecordResource(t, service)
+
+	resp := &fwresource.CreateResponse{State: state}
+	r.Create(context.Background(), fwresource.CreateRequest{Plan: testPlan(t, s, testRecordModel())}, resp)
+	if len(resp.Diagnostics) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %v", resp.Diagnostics)
+	}
+	if withPath, ok := resp.Diagnostics[0].(diag.DiagnosticWithPath); !ok || !withPath.Path().Equal(path.Root("ttl")) {
+		t.Errorf("ttl error should be attached to the ttl attribute, got %v", resp.Diagnostics[0])
+	}
+	if _, ok := resp.Diagnostics[1].(diag.DiagnosticWithPath); ok || resp.Diagnostics[1].Detail() != "records: too many" {
+		t.Errorf("unknown fields should be named in the detail only, got %v", resp.Diagnostics[1])
+	}
+}
+
+func TestDNSRecordResourceUpdateKeepsComputedIds(t *testing.T) {
+	service := &fakeRecordService{}
+	r, s, state := newTestRecordResource(t, service)
+
+	prior := testRecordModel()
+	prior.Id = types.StringValue("record-1")
+	prior.PTRRecordId = types.StringValue("ptr-1")
+	prior.OwnerRecordId = types.StringValue("owner-1")
+	planned := testRecordModel()
+	planned.Id = types.StringValue("record-1")
+	planned.Value = types.StringValue("192.0.2.2")
+
+	resp := &fwresource.UpdateResponse{State: state}
+	r.Update(context.Background(), fwresource.UpdateRequest{Plan: testPlan(t, s, planned), State: testState(t, s, prior)}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("update failed: %v", resp.Diagnostics)
+	}
+	updated := service.updated[0]
+	if updated.PTRRecordId.ValueString() != "ptr-1" || updated.OwnerRecordId.ValueString() != "owner-1" || updated.Value.ValueString() != "192.0.2.2" {
+		t.Errorf("update should keep the tracked records, got %+v", updated)
+	}
+}
+
+func TestDNSRecordResourceReadErrors(t *testing.T) {
+	testReadErrors(t, func(t *testing.T, diags diag.Diagnostics) (fwresource.Resource, tfsdk.State) {
+		service := &fakeRecordService{diags: diags}
+		r, s, _ := newTestRecordResource(t, service)
+
+		current := testRecordModel()
+		current.Id = types.StringValue("record-1")
+		current.PTRRecordId = types.StringNull()
+		current.OwnerRecordId = types.StringNull()
+		return r, testState(t, s, current)
+	})
+}
+
+func TestDNSRecordResourceDeletePropagatesDiagnostics(t *testing.T) {
+	service := &fakeRecordService{}
+	service.diags.AddError("Hetzner API Permission Denied", "forbidden")
+	r, s, state := newTestRecordResource(t, service)
+
+	current := testRecordModel()
+	current.Id = types.StringValue("record-1")
+	current.PTRRecordId = types.StringNull()
+	current.OwnerRecordId = types.StringNull()
+
+	resp := &fwresource.DeleteResponse{State: state}
+	r.Delete(context.Background(), fwresource.DeleteRequest{State: testState(t, s, current)}, resp)
+	if !resp.Diagnostics.HasError() || len(service.deleted) != 1 || service.deleted[0].Id.ValueString() != "record-1" {
+		t.Errorf("delete diagnostics should be propagated, got %v", resp.Diagnostics)
+	}
+}
+
+func TestDNSRecordResourcePlansDefaultTTL(t *testing.T) {
+	r, s, _ := newTestRecordResource(t, &fakeRecordService{})
+	config := testRecordModel()
+	config.TTL = types.Int64Null()
+	planned := testRecordModel()
+	planned.TTL = types.Int64Unknown()
+	modifyPlan := func(config dnsRecordResourceModel) *fwresource.ModifyPlanResponse {
+		plan := testPlan(t, s, planned)
+		resp := &fwresource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(context.Background(), fwresource.ModifyPlanRequest{Config: tfsdk.Config{Schema: s, Raw: testPlan(t, s, config).Raw}, Plan: plan}, resp)
+		return resp
+	}
+
+	if resp := modifyPlan(config); !resp.Diagnostics.HasError() {
+		t.Error("records without ttl and default TTL should fail")
+	}
+
+	ttl := int64(3600)
+	r.Defaults = hetzner.Defaults{DnsTTL: &ttl}
+	resp := modifyPlan(config)
+	var record dnsRecordResourceModel
+	resp.Plan.Get(context.Background(), &record)
+	if resp.Diagnostics.HasError() || record.TTL.ValueInt64() != 3600 {
+		t.Errorf("expected default TTL in plan, got %v: %v", record.TTL, resp.Diagnostics)
+	}
+
+	resp = modifyPlan(testRecordModel())
+	resp.Plan.Get(context.Background(), &record)
+	if !record.TTL.IsUnknown() {
+		t.Errorf("configured ttl should not be replaced, got %v", record.TTL)
+	}
+}
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/dns"
+)
+
+var _ resource.Resource = &dnsReverseZoneRecordsResource{}
+var _ resource.ResourceWithConfigure = &dnsReverseZoneRecordsResource{}
+
+type dnsReverseZoneRecordsResource struct {
+	Service dns.ReverseRecordService
+	Project string
+}
+
+type dnsReverseZoneRecordsResourceModel struct {
+	dns.ReverseRecords
+	Project  types.String   `tfsdk:"project"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewDnsReverseZoneRecordsResource() resource.Resource {
+	return &dnsReverseZoneRecordsResource{}
+}
+
+func (resource *dnsReverseZoneRecordsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	dataProvider, ok := req.ProviderData.(hetzner.Provider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *hetznerDataProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+	} else {
+		resource.Project = dataProvider.Project()
+		service, diags := dataProvider.DNSServices()
+		resp.Diagnostics.Append(diags...)
+		if !resp.Diagnostics.HasError() {
+			resource.Service = service.ReverseRecordService()
+		}
+	}
+}
+func (resource *dnsReverseZoneRecordsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_reverse_zone_records"
+}
+
+func (resource *dnsReverseZoneRecordsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = withTimeouts(ctx, withProject(dns.ReverseRecordsResourceSchema))
+}
+
+func (resource *dnsReverseZoneRecordsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var state dnsReverseZoneRecordsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &state)...)
+	state.Project = projectValue(resource.Project)
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+	diags := resource.Service.Create(ctx, &state.ReverseRecords)
+	setCreatedState(ctx, &state, state.Id, diags, resp)
+}
+
+func (resource *dnsReverseZoneRecordsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state dnsReverseZoneRecordsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	state.Project = checkProject(resource.Project, state.Project, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+	setReadState(ctx, &state, resource.Service.Read(ctx, &state.ReverseRecords), resp)
+}
+
+func (resource *dnsReverseZoneRecordsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// zone_id forces replacement, so only the planned state is persisted.
+	var state dnsReverseZoneRecordsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &state)...)
+	state.Project = checkProject(resource.Project, state.Project, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (resource *dnsReverseZoneRecordsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state dnsReverseZoneRecordsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	state.Project = checkProject(resource.Project, state.Project, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+	resp.Diagnostics.Append(resource.Service.Delete(ctx, &state.ReverseRecords)...)
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/dns"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/dns/dnstest"
+)
+
+func TestAccDNSReverseZoneRecordsResource(t *testing.T) {
+	server := testAccServer(t)
+	zone := server.AddZone("example.com", 3600)
+	server.AddRecord(zone.Id, "A", "www", "192.0.2.1", nil)
+	server.AddRecord(zone.Id, "A", "mail", "192.0.2.2", nil)
+	reverseZone := server.AddZone("2.0.192.in-addr.arpa", 3600)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "hetzner_dns_reverse_zone_records" "test" {
+  zone_id = %q
+}
+`, zone.Id),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hetzner_dns_reverse_zone_records.test", "records.#", "2"),
+					resource.TestCheckResourceAttr("hetzner_dns_reverse_zone_records.test", "records.0.zone_id", reverseZone.Id),
+					resource.TestCheckResourceAttr("hetzner_dns_reverse_zone_records.test", "records.0.name", "1"),
+					resource.TestCheckResourceAttr("hetzner_dns_reverse_zone_records.test", "records.0.value", "www.example.com."),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDNSReverseZoneRecordsResourcePartialFailure(t *testing.T) {
+	server := testAccServer(t)
+	zone := server.AddZone("example.com", 3600)
+	for _, value := range []string{"192.0.2.1", "192.0.2.2", "192.0.2.3"} {
+		server.AddRecord(zone.Id, "A", "www", value, nil)
+	}
+	reverseZone := server.AddZone("2.0.192.in-addr.arpa", 3600)
+	server.InjectFault(dnstest.Fault{Method: http.MethodPost, Path: "/records", Call: 2, StatusCode: http.StatusInternalServerError})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		// PTR records created before the failure are tracked in state and
+		// removed on destroy.
+		CheckDestroy: func(*terraform.State) error {
+			if records := server.Records(reverseZone.Id); len(records) != 4 {
+				return fmt.Errorf("PTR records were left behind: %v", records)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + fmt.Sprintf(`
+resource "hetzner_dns_reverse_zone_records" "test" {
+  zone_id = %q
+}
+`, zone.Id),
+				ExpectError: regexp.MustCompile("500 Internal Server Error"),
+			},
+		},
+	})
+}
+
+type fakeReverseRecordService struct {
+	diags diag.Diagnostics
+}
+
+func (s *fakeReverseRecordService) Create(ctx context.Context, records *dns.ReverseRecords) diag.Diagnostics {
+	return s.diags
+}
+
+func (s *fakeReverseRecordService) Read(ctx context.Context, records *dns.ReverseRecords) diag.Diagnostics {
+	return s.diags
+}
+
+func (s *fakeReverseRecordService) Delete(ctx context.Context, records *dns.ReverseRecords) diag.Diagnostics {
+	return s.diags
+}
+
+func TestDNSReverseZoneRecordsResourceReadErrors(t *testing.T) {
+	testReadErrors(t, func(t *testing.T, diags diag.Diagnostics) (fwresource.Resource, tfsdk.State) {
+		service := &fakeReverseRecordService{diags: diags}
+		r := NewDnsReverseZoneRecordsResource().(*dnsReverseZoneRecordsResource)
+		configureTestResource(t, r, &fakeDNSServices{reverse: service})
+		s, _ := testResourceSchema(t, r)
+		return r, testState(t, s, dnsReverseZoneRecordsResourceModel{
+			ReverseRecords: dns.ReverseRecords{
+				Id:      types.StringValue("zone-1"),
+				ZoneId:  types.StringValue("zone-1"),
+				Records: []dns.ReverseRecord{},
+			},
+			Project:  types.StringNull(),
+			Timeouts: testTimeouts(nil),
+		})
+	})
+}
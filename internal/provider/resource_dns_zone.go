@@ -4,18 +4,29 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/api"
 	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/dns"
 )
 
 var _ resource.Resource = &dnsZoneResource{}
 var _ resource.ResourceWithConfigure = &dnsZoneResource{}
 var _ resource.ResourceWithImportState = &dnsZoneResource{}
+var _ resource.ResourceWithModifyPlan = &dnsZoneResource{}
 
 type dnsZoneResource struct {
-	Service dns.ZoneService
+	Service  dns.ZoneService
+	Defaults hetzner.Defaults
+	Project  string
+}
+
+type dnsZoneResourceModel struct {
+	dns.ZoneResource
+	Project  types.String   `tfsdk:"project"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func NewDnsZoneResource() resource.Resource {
@@ -34,6 +45,8 @@ func (resource *dnsZoneResource) Configure(ctx context.Context, req resource.Con
 			fmt.Sprintf("Expected *hetznerDataProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 	} else {
+		resource.Project = dataProvider.Project()
+		resource.Defaults = dataProvider.Defaults()
 		service, diags := dataProvider.DNSServices()
 		resp.Diagnostics.Append(diags...)
 		if !resp.Diagnostics.HasError() {
@@ -46,36 +59,60 @@ func (resource *dnsZoneResource) Metadata(ctx context.Context, req resource.Meta
 }
 
 func (resource *dnsZoneResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
-	resp.Schema = dns.ZoneResourceSchema
+	resp.Schema = withTimeouts(ctx, withProject(dns.ZoneResourceSchema))
+}
+
+func (resource *dnsZoneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planDefaultTTL(ctx, resource.Defaults, req, resp)
 }
 
 func (resource *dnsZoneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var state dns.Zone
+	var state dnsZoneResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &state)...)
-	resp.Diagnostics.Append(resource.Service.Create(&state)...)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	state.Project = projectValue(resource.Project)
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+	diags := api.WithFieldPaths(resource.Service.Create(ctx, &state.ZoneResource), dns.ZoneResourceFieldPaths)
+	setCreatedState(ctx, &state, state.Id, diags, resp)
 }
 
 func (resource *dnsZoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state dns.Zone
+	var state dnsZoneResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
-	resource.Service.Read(&state)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	state.Project = checkProject(resource.Project, state.Project, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+	setReadState(ctx, &state, resource.Service.Read(ctx, &state.Zone), resp)
 }
 
 func (resource *dnsZoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var state dns.Zone
+	var state dnsZoneResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &state)...)
-	resp.Diagnostics.Append(resource.Service.Update(&state)...)
+	state.Project = checkProject(resource.Project, state.Project, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+	resp.Diagnostics.Append(api.WithFieldPaths(resource.Service.Update(ctx, &state.Zone), dns.ZoneResourceFieldPaths)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (resource *dnsZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state dns.Zone
+	var state dnsZoneResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
-	resp.Diagnostics.Append(resource.Service.Delete(&state)...)
+	state.Project = checkProject(resource.Project, state.Project, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+	resp.Diagnostics.Append(resource.Service.Delete(ctx, &state.Zone)...)
 }
 
 func (r *dnsZoneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importProjectState(ctx, r.Project, req, resp)
 }
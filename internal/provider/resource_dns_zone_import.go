@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/dns"
+)
+
+var _ resource.Resource = &dnsZoneImportResource{}
+var _ resource.ResourceWithConfigure = &dnsZoneImportResource{}
+
+type dnsZoneImportResource struct {
+	Service dns.ZoneImportService
+	Project string
+}
+
+type dnsZoneImportResourceModel struct {
+	dns.ZoneImport
+	Project  types.String   `tfsdk:"project"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewDnsZoneImportResource() resource.Resource {
+	return &dnsZoneImportResource{}
+}
+
+func (resource *dnsZoneImportResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	dataProvider, ok := req.ProviderData.(hetzner.Provider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *hetznerDataProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+	} else {
+		resource.Project = dataProvider.Project()
+		service, diags := dataProvider.DNSServices()
+		resp.Diagnostics.Append(diags...)
+		if !resp.Diagnostics.HasError() {
+			resource.Service = service.ZoneImportService()
+		}
+	}
+}
+
+func (resource *dnsZoneImportResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_zone_import"
+}
+
+func (resource *dnsZoneImportResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = withTimeouts(ctx, withProject(dns.ZoneImportResourceSchema))
+}
+
+func (resource *dnsZoneImportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var state dnsZoneImportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &state)...)
+	state.Project = projectValue(resource.Project)
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+	diags := resource.Service.Create(ctx, &state.ZoneImport)
+	setCreatedState(ctx, &state, state.Id, diags, resp)
+}
+
+// The transfer is a one-off operation, so Read, Update and Delete only
+// maintain the Terraform state.
+func (resource *dnsZoneImportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state dnsZoneImportResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	state.Project = checkProject(resource.Project, state.Project, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (resource *dnsZoneImportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state dnsZoneImportResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	// Only the timeouts change in place, the transferred records are kept.
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("timeouts"), &state.Timeouts)...)
+	state.Project = checkProject(resource.Project, state.Project, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (resource *dnsZoneImportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/dns"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/dns/dnstest"
+)
+
+func TestAccDNSZoneImportResource(t *testing.T) {
+	server := testAccServer(t)
+	zone := server.AddZone("example.com", 3600)
+	nameserver := dnstest.StartAXFRServer(t, "example.com", []string{
+		"example.com. 3600 IN SOA ns1.example.com. hostmaster.example.com. 1 7200 3600 1209600 3600",
+		"example.com. 3600 IN NS ns1.example.com.",
+		"www.example.com. 300 IN A 192.0.2.1",
+	}, "")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "hetzner_dns_zone_import" "test" {
+  zone_name  = "example.com"
+  nameserver = %q
+  zone_id    = %q
+}
+`, nameserver, zone.Id),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hetzner_dns_zone_import.test", "records.#", "3"),
+					func(*terraform.State) error {
+						for _, record := range server.Records(zone.Id) {
+							if record.Type == "A" && record.Name == "www" && record.Value == "192.0.2.1" {
+								return nil
+							}
+						}
+						return fmt.Errorf("record www was not uploaded: %v", server.Records(zone.Id))
+					},
+				),
+			},
+		},
+	})
+}
+
+type fakeZoneImportService struct {
+	diags diag.Diagnostics
+}
+
+func (s *fakeZoneImportService) Create(ctx context.Context, zoneImport *dns.ZoneImport) diag.Diagnostics {
+	return s.diags
+}
+
+func testZoneImportModel() dnsZoneImportResourceModel {
+	return dnsZoneImportResourceModel{
+		ZoneImport: dns.ZoneImport{
+			Id:            types.StringValue("example.com@192.0.2.53"),
+			ZoneName:      types.StringValue("example.com"),
+			Nameserver:    types.StringValue("192.0.2.53"),
+			TSIGKeyName:   types.StringNull(),
+			TSIGAlgorithm: types.StringNull(),
+			TSIGSecret:    types.StringNull(),
+			ZoneId:        types.StringNull(),
+			Records: []dns.ImportedRecord{{
+				Id:    types.StringNull(),
+				Type:  types.StringValue("A"),
+				Name:  types.StringValue("www"),
+				Value: types.StringValue("192.0.2.1"),
+				TTL:   types.Int64Value(300),
+			}},
+		},
+		Project:  types.StringNull(),
+		Timeouts: testTimeouts(nil),
+	}
+}
+
+func TestDNSZoneImportResourceCreateFailure(t *testing.T) {
+	service := &fakeZoneImportService{}
+	service.diags.AddError("Zone Transfer Error", "transfer refused")
+	r := NewDnsZoneImportResource().(*dnsZoneImportResource)
+	configureTestResource(t, r, &fakeDNSServices{imports: service})
+	s, state := testResourceSchema(t, r)
+
+	planned := testZoneImportModel()
+	planned.Id = types.StringUnknown()
+	planned.Records = nil
+	resp := &fwresource.CreateResponse{State: state}
+	r.Create(context.Background(), fwresource.CreateRequest{Plan: testPlan(t, s, planned)}, resp)
+	if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "Zone Transfer Error" {
+		t.Errorf("service diagnostics should be propagated, got %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Errorf("failed transfers should not store state, got %v", resp.State.Raw)
+	}
+}
+
+func TestDNSZoneImportResourceUpdateKeepsRecords(t *testing.T) {
+	ctx := context.Background()
+	r := NewDnsZoneImportResource().(*dnsZoneImportResource)
+	configureTestResource(t, r, &fakeDNSServices{})
+	s, state := testResourceSchema(t, r)
+
+	prior := testZoneImportModel()
+	planned := prior
+	planned.Timeouts = testTimeouts(map[string]string{"create": "1m"})
+	plan := testPlan(t, s, planned)
+	// Without UseStateForUnknown the records are planned as unknown.
+	if diags := plan.SetAttribute(ctx, path.Root("records"), types.ListUnknown(s.Attributes["records"].GetType().(types.ListType).ElemType)); diags.HasError() {
+		t.Fatalf("invalid plan: %v", diags)
+	}
+
+	resp := &fwresource.UpdateResponse{State: state}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: testState(t, s, prior)}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("update failed: %v", resp.Diagnostics)
+	}
+	var updated dnsZoneImportResourceModel
+	resp.State.Get(ctx, &updated)
+	if len(updated.Records) != 1 || updated.Records[0].Name.ValueString() != "www" || !updated.Timeouts.Equal(planned.Timeouts) {
+		t.Errorf("timeout changes should keep the records, got %+v", updated)
+	}
+}
@@ -0,0 +1,268 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/dns"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/dns/dnstest"
+)
+
+func TestAccDNSZoneResource(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			if zones := server.Zones(); len(zones) != 0 {
+				return fmt.Errorf("zones were not destroyed: %v", zones)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDNSZoneConfig("bücher.example", 3600),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("hetzner_dns_zone.test", "id"),
+					resource.TestCheckResourceAttr("hetzner_dns_zone.test", "name_unicode", "bücher.example"),
+					resource.TestCheckResourceAttr("hetzner_dns_zone.test", "ttl", "3600"),
+					resource.TestCheckResourceAttr("hetzner_dns_zone.test", "ns.#", "3"),
+					resource.TestCheckResourceAttr("hetzner_dns_zone.test", "status", "verified"),
+				),
+			},
+			{
+				ResourceName:      "hetzner_dns_zone.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Imported zones carry the punycode name.
+				ImportStateVerifyIgnore: []string{"name"},
+			},
+			{
+				Config: testAccDNSZoneConfig("bücher.example", 7200),
+				Check:  resource.TestCheckResourceAttr("hetzner_dns_zone.test", "ttl", "7200"),
+			},
+		},
+	})
+}
+
+func TestAccDNSZoneResourceCopyRecords(t *testing.T) {
+	server := testAccServer(t)
+	source := server.AddZone("example.com", 3600)
+	server.AddRecord(source.Id, "A", "www", "192.0.2.1", nil)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "hetzner_dns_zone" "test" {
+  name                      = "staging.example.com"
+  ttl                       = 3600
+  copy_records_from_zone_id = %q
+}
+`, source.Id),
+				Check: func(state *terraform.State) error {
+					zoneId := state.RootModule().Resources["hetzner_dns_zone.test"].Primary.ID
+					for _, record := range server.Records(zoneId) {
+						if record.Type == "A" && record.Name == "www" && record.Value == "192.0.2.1" {
+							return nil
+						}
+					}
+					return fmt.Errorf("record www was not copied: %v", server.Records(zoneId))
+				},
+			},
+		},
+	})
+}
+
+func TestAccDNSZoneResourceRetries(t *testing.T) {
+	server := testAccServer(t)
+	server.InjectFault(dnstest.Fault{Method: http.MethodPost, Path: "/zones", Times: 2, StatusCode: http.StatusTooManyRequests, RetryAfter: "1"})
+	server.InjectFault(dnstest.Fault{Method: http.MethodGet, Path: "/zones/", StatusCode: http.StatusBadGateway})
+	server.InjectFault(dnstest.Fault{Method: http.MethodGet, Path: "/zones/", Call: 3, Drop: true})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + testAccDNSZoneConfig("example.com", 3600),
+				Check: func(*terraform.State) error {
+					if zones := server.Zones(); len(zones) != 1 {
+						return fmt.Errorf("expected exactly one zone, got %v", zones)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func testAccDNSZoneConfig(name string, ttl int) string {
+	return fmt.Sprintf(`
+resource "hetzner_dns_zone" "test" {
+  name = %q
+  ttl  = %d
+}
+`, name, ttl)
+}
+
+type fakeZoneService struct {
+	diags diag.Diagnostics
+}
+
+func (s *fakeZoneService) List(ctx context.Context, zones *dns.Zones) diag.Diagnostics {
+	return s.diags
+}
+
+func (s *fakeZoneService) Read(ctx context.Context, zone *dns.Zone) diag.Diagnostics {
+	zone.TTL = types.Int64Value(7200)
+	return s.diags
+}
+
+func (s *fakeZoneService) Create(ctx context.Context, zone *dns.ZoneResource) diag.Diagnostics {
+	zone.Id = types.StringValue("zone-1")
+	zone.NS = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("hydrogen.ns.hetzner.com.")})
+	zone.Paused = types.BoolValue(false)
+	zone.Status = types.StringValue("verified")
+	return s.diags
+}
+
+func (s *fakeZoneService) Update(ctx context.Context, zone *dns.Zone) diag.Diagnostics {
+	return s.diags
+}
+
+func (s *fakeZoneService) Delete(ctx context.Context, zone *dns.Zone) diag.Diagnostics {
+	return s.diags
+}
+
+func TestDNSZoneResourceCreateAndRead(t *testing.T) {
+	ctx := context.Background()
+	r := NewDnsZoneResource().(*dnsZoneResource)
+	configureTestResource(t, r, &fakeDNSServices{zones: &fakeZoneService{}})
+	s, state := testResourceSchema(t, r)
+
+	planned := dnsZoneResourceModel{
+		ZoneResource: dns.ZoneResource{
+			Zone: dns.Zone{
+				Id:          types.StringUnknown(),
+				Name:        dns.NewDomainNameValue("example.com"),
+				NameUnicode: types.StringValue("example.com"),
+				NS:          types.ListUnknown(types.StringType),
+				Paused:      types.BoolUnknown(),
+				Status:      types.StringUnknown(),
+				TTL:         types.Int64Value(3600),
+			},
+			CopyRecordsFromZoneId: types.StringNull(),
+		},
+		Timeouts: testTimeouts(nil),
+	}
+	createResp := &fwresource.CreateResponse{State: state}
+	r.Create(ctx, fwresource.CreateRequest{Plan: testPlan(t, s, planned)}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("create failed: %v", createResp.Diagnostics)
+	}
+
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read failed: %v", readResp.Diagnostics)
+	}
+	var zone dnsZoneResourceModel
+	readResp.State.Get(ctx, &zone)
+	if zone.Id.ValueString() != "zone-1" || zone.Status.ValueString() != "verified" || zone.TTL.ValueInt64() != 7200 {
+		t.Errorf("unexpected state %+v", zone)
+	}
+}
+
+func testZoneModel() dnsZoneResourceModel {
+	return dnsZoneResourceModel{
+		ZoneResource: dns.ZoneResource{
+			Zone: dns.Zone{
+				Id:          types.StringValue("zone-1"),
+				Name:        dns.NewDomainNameValue("example.com"),
+				NameUnicode: types.StringValue("example.com"),
+				NS:          types.ListNull(types.StringType),
+				Paused:      types.BoolValue(false),
+				Status:      types.StringValue("verified"),
+				TTL:         types.Int64Value(3600),
+			},
+			CopyRecordsFromZoneId: types.StringNull(),
+		},
+		Project:  types.StringNull(),
+		Timeouts: testTimeouts(nil),
+	}
+}
+
+func TestDNSZoneResourceReadErrors(t *testing.T) {
+	testReadErrors(t, func(t *testing.T, diags diag.Diagnostics) (fwresource.Resource, tfsdk.State) {
+		service := &fakeZoneService{diags: diags}
+		r := NewDnsZoneResource().(*dnsZoneResource)
+		configureTestResource(t, r, &fakeDNSServices{zones: service})
+		s, _ := testResourceSchema(t, r)
+		return r, testState(t, s, testZoneModel())
+	})
+}
+
+func TestDNSZoneResourceChecksProject(t *testing.T) {
+	ctx := context.Background()
+	r := NewDnsZoneResource().(*dnsZoneResource)
+	configureTestResource(t, r, &fakeDNSServices{zones: &fakeZoneService{}})
+	r.Project = "production"
+	s, _ := testResourceSchema(t, r)
+
+	zone := testZoneModel()
+	read := func(zone dnsZoneResourceModel) *fwresource.ReadResponse {
+		state := testState(t, s, zone)
+		resp := &fwresource.ReadResponse{State: state}
+		r.Read(ctx, fwresource.ReadRequest{State: state}, resp)
+		return resp
+	}
+
+	// States without project, e.g. of imported zones, adopt the project.
+	resp := read(zone)
+	var adopted dnsZoneResourceModel
+	resp.State.Get(ctx, &adopted)
+	if resp.Diagnostics.HasError() || adopted.Project.ValueString() != "production" {
+		t.Errorf("expected project production, got %v: %v", adopted.Project, resp.Diagnostics)
+	}
+
+	zone.Project = types.StringValue("staging")
+	if resp := read(zone); !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "Resource Of Another Project" {
+		t.Errorf("zones of another project should fail, got %v", resp.Diagnostics)
+	}
+}
+
+func TestDNSZoneResourceImportsProjectIds(t *testing.T) {
+	ctx := context.Background()
+	r := NewDnsZoneResource().(*dnsZoneResource)
+	configureTestResource(t, r, &fakeDNSServices{zones: &fakeZoneService{}})
+	r.Project = "production"
+	_, state := testResourceSchema(t, r)
+
+	importState := func(id string) (*fwresource.ImportStateResponse, dnsZoneResourceModel) {
+		resp := &fwresource.ImportStateResponse{State: state}
+		r.ImportState(ctx, fwresource.ImportStateRequest{ID: id}, resp)
+		var imported dnsZoneResourceModel
+		resp.State.Get(ctx, &imported)
+		return resp, imported
+	}
+
+	for _, id := range []string{"zone-1", "production/zone-1"} {
+		resp, imported := importState(id)
+		if resp.Diagnostics.HasError() || imported.Id.ValueString() != "zone-1" || imported.Project.ValueString() != "production" {
+			t.Errorf("import of %s should set id zone-1 and project production, got %v and %v: %v", id, imported.Id, imported.Project, resp.Diagnostics)
+		}
+	}
+	if resp, _ := importState("staging/zone-1"); !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "Resource Of Another Project" {
+		t.Errorf("import of another project should fail, got %v", resp.Diagnostics)
+	}
+}
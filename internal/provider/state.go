@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/opsheaven/terraform-provider-hetzner/internal/hetzner/api"
+)
+
+// setCreatedState stores the state after a create with the diagnostics of the
+// service. When nothing was created no state is stored, partially created
+// resources are kept in state so that they are not orphaned.
+func setCreatedState(ctx context.Context, state any, id types.String, diags diag.Diagnostics, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() && id.IsUnknown() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// setReadState stores the state after a read with the diagnostics of the
+// service. Resources deleted outside of Terraform are removed from state, the
+// state is kept on other errors.
+func setReadState(ctx context.Context, state any, diags diag.Diagnostics, resp *resource.ReadResponse) {
+	if api.HasNotFound(diags) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+// defaultTimeout limits the operations of resources without a timeouts block.
+const defaultTimeout = 20 * time.Minute
+
+// withTimeouts adds the timeouts block with create, read, update and delete
+// timeouts to the schema of a resource.
+func withTimeouts(ctx context.Context, s schema.Schema) schema.Schema {
+	blocks := map[string]schema.Block{}
+	maps.Copy(blocks, s.Blocks)
+	blocks["timeouts"] = timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true})
+	s.Blocks = blocks
+	return s
+}
+
+// withTimeout returns a context with the deadline of the configured timeout
+// of an operation, e.g. Timeouts.Create, so stuck API calls fail.
+func withTimeout(ctx context.Context, timeout func(context.Context, time.Duration) (time.Duration, diag.Diagnostics), diagnostics *diag.Diagnostics) (context.Context, context.CancelFunc) {
+	duration, diags := timeout(ctx, defaultTimeout)
+	diagnostics.Append(diags...)
+	return context.WithTimeout(ctx, duration)
+}
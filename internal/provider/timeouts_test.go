@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testTimeouts returns a timeouts block with the given timeouts, or a null
+// block without timeouts.
+func testTimeouts(values map[string]string) timeouts.Value {
+	attributeTypes := map[string]attr.Type{"create": types.StringType, "read": types.StringType, "update": types.StringType, "delete": types.StringType}
+	if values == nil {
+		return timeouts.Value{Object: types.ObjectNull(attributeTypes)}
+	}
+	attributes := map[string]attr.Value{}
+	for name := range attributeTypes {
+		if value, ok := values[name]; ok {
+			attributes[name] = types.StringValue(value)
+		} else {
+			attributes[name] = types.StringNull()
+		}
+	}
+	return timeouts.Value{Object: types.ObjectValueMust(attributeTypes, attributes)}
+}
+
+func TestResourceTimeoutsLimitServiceCalls(t *testing.T) {
+	for name, test := range map[string]struct {
+		timeouts timeouts.Value
+		expected time.Duration
+	}{
+		"default":    {timeouts: testTimeouts(nil), expected: defaultTimeout},
+		"configured": {timeouts: testTimeouts(map[string]string{"create": "1m"}), expected: time.Minute},
+	} {
+		t.Run(name, func(t *testing.T) {
+			service := &fakeRecordService{}
+			r, s, state := newTestRecordResource(t, service)
+			planned := testRecordModel()
+			planned.Timeouts = test.timeouts
+
+			start := time.Now()
+			resp := &fwresource.CreateResponse{State: state}
+			r.Create(context.Background(), fwresource.CreateRequest{Plan: testPlan(t, s, planned)}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("create failed: %v", resp.Diagnostics)
+			}
+			if timeout := service.deadline.Sub(start); timeout < test.expected || timeout > test.expected+time.Second {
+				t.Errorf("expected %s timeout, got %s", test.expected, timeout)
+			}
+		})
+	}
+}
+
+func TestResourceSchemasHaveTimeouts(t *testing.T) {
+	for _, newResource := range (&hetznerProvider{}).Resources(context.Background()) {
+		r := newResource()
+		resp := &fwresource.SchemaResponse{}
+		r.Schema(context.Background(), fwresource.SchemaRequest{}, resp)
+		if _, ok := resp.Schema.Blocks["timeouts"]; !ok {
+			t.Errorf("%T has no timeouts block", r)
+		}
+	}
+}